@@ -0,0 +1,42 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+//go:build !race
+
+// The race detector makes sync.Pool drop buffers at random, so allocations are only
+// counted without it.
+
+package logger
+
+import "testing"
+
+func TestEventLogAllocs(t *testing.T) {
+	defer discardStderr(t)()
+	test := New(true, true)
+	test.SetLogLevel(Normal)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		test.Debug.Log("Test message")
+	})
+	if allocs != 0 {
+		t.Errorf("Disabled event allocated, expected '%v' got '%v'", 0, allocs)
+	}
+
+	allocs = testing.AllocsPerRun(100, func() {
+		test.Debug.LogFunc(func() string { return "Test message" })
+	})
+	if allocs != 0 {
+		t.Errorf("Disabled lazy event allocated, expected '%v' got '%v'", 0, allocs)
+	}
+
+	test.Error.SetColorFormat(Timestamp | Prefix | Message)
+	allocs = testing.AllocsPerRun(100, func() {
+		test.Error.Log("Test message")
+	})
+	// the returned string is the only allocation
+	if allocs > 1 {
+		t.Errorf("Enabled event allocated too much, expected '%v' got '%v'", 1, allocs)
+	}
+}
@@ -0,0 +1,255 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// The OverflowPolicy type represents what an asynchronous logger does when its queue is
+// full.
+type OverflowPolicy uint8
+
+// Constants for defining OverflowPolicies.
+const (
+	Block      OverflowPolicy = iota // Log waits until there is room in the queue.
+	DropNewest                       // The entry being logged is dropped.
+	DropOldest                       // The oldest queued entry is dropped to make room.
+)
+
+// A record represents a rendered entry waiting to be written by Logger.write.
+type record struct {
+	console []byte
+	path    string
+	file    []byte
+	entry   *Entry        // passed to the sinks, nil when there are none
+	sinks   []Sink        // sinks of the logger when the entry was logged
+	outputs uint64        // output set of the entry, see Logger.outputs
+	flushed chan struct{} // set on flush markers instead of an entry
+}
+
+// An asyncQueue represents the bounded queue and background writer of an
+// asynchronous logger.
+type asyncQueue struct {
+	mu      sync.RWMutex
+	closed  bool
+	policy  OverflowPolicy
+	records chan record
+	quit    chan struct{}
+	done    chan struct{}
+	dropped uint64
+	expired atomic.Bool // set when Close gave up on the queue, records left skip the sinks
+	errMu   sync.Mutex
+	err     error
+}
+
+// SetAsync switches the logger to asynchronous mode. Entries are still rendered when Log
+// is called, but are written to their outputs by a background goroutine draining a
+// queue of the given size. Use Flush to wait for queued entries and Close to stop the
+// background goroutine.
+func (l *Logger) SetAsync(size int, policy OverflowPolicy) error {
+	if size < 1 {
+		return errors.New("Invalid queue size")
+	}
+	if policy > DropOldest {
+		return errors.New("Invalid overflow policy")
+	}
+	if l.async != nil && !l.async.isClosed() {
+		return errors.New("Logger is already asynchronous")
+	}
+
+	q := &asyncQueue{
+		policy:  policy,
+		records: make(chan record, size),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go q.run(l.write)
+	l.async = q
+	return nil
+}
+
+// Dropped returns the number of entries dropped because the asynchronous queue was full.
+func (l *Logger) Dropped() uint64 {
+	if l.async == nil {
+		return 0
+	}
+	return atomic.LoadUint64(&l.async.dropped)
+}
+
+// Flush waits until every entry queued so far has been written, or until the timeout
+// expires. It returns the first write error that happened in the background since the
+// last Flush. Flush does nothing for a synchronous logger.
+func (l *Logger) Flush(timeout time.Duration) error {
+	q := l.async
+	if q == nil || q.isClosed() {
+		return nil
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	marker := record{flushed: make(chan struct{})}
+	q.mu.RLock()
+	select {
+	case q.records <- marker:
+		q.mu.RUnlock()
+	case <-timer.C:
+		q.mu.RUnlock()
+		return errors.New("Timed out flushing log queue")
+	}
+
+	select {
+	case <-marker.flushed:
+		return q.takeErr()
+	case <-timer.C:
+		return errors.New("Timed out flushing log queue")
+	}
+}
+
+// Close flushes the asynchronous queue and stops the background goroutine, then closes the
+// sinks of the logger. The sinks are closed even if the queue did not drain within the
+// timeout, entries still queued then are not passed to them, though a write already in
+// progress finishes. Entries logged afterwards
+// are written synchronously again.
+func (l *Logger) Close(timeout time.Duration) error {
+	q := l.async
+	if q == nil || q.isClosed() {
+		return l.closeSinks()
+	}
+
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	// the background goroutine drains the queue before it stops
+	close(q.quit)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-q.done:
+	case <-timer.C:
+		q.expired.Store(true)
+		if serr := l.closeSinks(); serr != nil {
+			return errors.New("Timed out closing log queue: " + serr.Error())
+		}
+		return errors.New("Timed out closing log queue")
+	}
+	err := q.takeErr()
+	if serr := l.closeSinks(); err == nil {
+		err = serr
+	}
+	return err
+}
+
+// output writes the record right away, or queues it when the logger is asynchronous.
+func (l *Logger) output(r record) error {
+	q := l.async
+	if q == nil {
+		return l.write(r)
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return l.write(r)
+	}
+	// the rendered entry lives in a pooled buffer, queue a copy
+	r.console = append([]byte(nil), r.console...)
+	r.file = append([]byte(nil), r.file...)
+	q.enqueue(r)
+	return nil
+}
+
+// enqueue adds a record to the queue according to the overflow policy.
+func (q *asyncQueue) enqueue(r record) {
+	switch q.policy {
+	case Block:
+		q.records <- r
+	case DropNewest:
+		select {
+		case q.records <- r:
+		default:
+			atomic.AddUint64(&q.dropped, 1)
+		}
+	case DropOldest:
+		for {
+			select {
+			case q.records <- r:
+				return
+			default:
+			}
+			select {
+			case old := <-q.records:
+				if old.flushed != nil {
+					// never drop a flush marker, drop the new entry instead
+					q.records <- old
+					atomic.AddUint64(&q.dropped, 1)
+					return
+				}
+				atomic.AddUint64(&q.dropped, 1)
+			default:
+			}
+		}
+	}
+}
+
+// run writes queued records until the queue is closed, then drains what is left.
+func (q *asyncQueue) run(write func(record) error) {
+	defer close(q.done)
+	for {
+		select {
+		case r := <-q.records:
+			q.handle(r, write)
+		case <-q.quit:
+			for {
+				select {
+				case r := <-q.records:
+					q.handle(r, write)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// handle writes a single record, or releases a waiting Flush for a flush marker.
+func (q *asyncQueue) handle(r record, write func(record) error) {
+	if r.flushed != nil {
+		close(r.flushed)
+		return
+	}
+	if q.expired.Load() {
+		// the sinks were closed by Close
+		r.entry, r.sinks = nil, nil
+	}
+	if err := write(r); err != nil {
+		q.errMu.Lock()
+		if q.err == nil {
+			q.err = err
+		}
+		q.errMu.Unlock()
+	}
+}
+
+// takeErr returns and clears the first background write error.
+func (q *asyncQueue) takeErr() error {
+	q.errMu.Lock()
+	defer q.errMu.Unlock()
+	err := q.err
+	q.err = nil
+	return err
+}
+
+// isClosed returns true once Close has been called on the queue.
+func (q *asyncQueue) isClosed() bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.closed
+}
@@ -0,0 +1,159 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoggerSetAsync(t *testing.T) {
+	test := New()
+	if err := test.SetAsync(0, Block); err == nil {
+		t.Errorf("Invalid queue size did not trigger error")
+	}
+	if err := test.SetAsync(8, DropOldest+1); err == nil {
+		t.Errorf("Invalid overflow policy did not trigger error")
+	}
+	if err := test.SetAsync(8, DropNewest); err != nil {
+		t.Errorf("Error setting async mode: %v", err)
+	}
+	if err := test.SetAsync(8, DropNewest); err == nil {
+		t.Errorf("Setting async mode twice did not trigger error")
+	}
+	if err := test.Close(time.Second); err != nil {
+		t.Errorf("Error closing async logger: %v", err)
+	}
+	if err := test.SetAsync(8, Block); err != nil {
+		t.Errorf("Error setting async mode after close: %v", err)
+	}
+	test.Close(time.Second)
+}
+
+// blockingSink is a memorySink whose writes wait until release is closed.
+type blockingSink struct {
+	memorySink
+	release chan struct{}
+}
+
+func (s *blockingSink) Write(en *Entry) error {
+	<-s.release
+	return s.memorySink.Write(en)
+}
+
+func TestLoggerAsyncCloseTimeout(t *testing.T) {
+	defer discardStderr(t)()
+	test := New(false, false)
+	sink := &blockingSink{release: make(chan struct{})}
+	test.AddSink(sink)
+	test.SetAsync(4, Block)
+	test.Error.Log("Stuck")
+	test.Error.Log("Queued")
+
+	if err := test.Close(50 * time.Millisecond); err == nil {
+		t.Errorf("Close did not time out")
+	}
+	sink.mu.Lock()
+	closed := sink.closed
+	sink.mu.Unlock()
+	if !closed {
+		t.Errorf("Sink was not closed when closing the queue timed out")
+	}
+	if len(test.sinks) != 0 {
+		t.Errorf("Sinks were not removed, got '%v'", len(test.sinks))
+	}
+	close(sink.release)
+	<-test.async.done
+	if actual := sink.messages(); len(actual) != 1 || actual[0] != "Stuck" {
+		t.Errorf("Queued entry was passed to a closed sink, got '%v'", actual)
+	}
+}
+
+func TestLoggerAsyncFlush(t *testing.T) {
+	test := New(false, false)
+	if err := test.SaveLog("asynclog"); err != nil {
+		t.Errorf("Error creating save log: %v", err)
+	}
+	defer os.RemoveAll("asynclog")
+	test.SetAsync(4, Block)
+
+	for i := 0; i < 10; i++ {
+		if _, err := test.Error.Log("Test message %v", i); err != nil {
+			t.Errorf("Error logging event: %v", err)
+		}
+	}
+	if err := test.Flush(time.Second); err != nil {
+		t.Errorf("Error flushing log: %v", err)
+	}
+
+	b, err := ioutil.ReadFile(test.logPath)
+	if err != nil {
+		t.Errorf("Error reading log file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 10 {
+		t.Fatalf("Wrong number of lines, expected '%v' got '%v'", 10, len(lines))
+	}
+	expected := "ERROR: Test message 9"
+	if actual := trimSpaces(lines[9]); actual != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, actual)
+	}
+
+	if err := test.Close(time.Second); err != nil {
+		t.Errorf("Error closing async logger: %v", err)
+	}
+	test.Error.Log("After close")
+	b, _ = ioutil.ReadFile(test.logPath)
+	if !strings.Contains(string(b), "After close") {
+		t.Errorf("Entry logged after close was not written")
+	}
+	if test.Dropped() != 0 {
+		t.Errorf("Entries dropped with blocking policy, got '%v'", test.Dropped())
+	}
+}
+
+func TestLoggerAsyncWriteError(t *testing.T) {
+	test := New(false, false)
+	test.SetAsync(4, Block)
+	test.output(record{path: "missing/dir/log.log"})
+	if err := test.Close(time.Second); err == nil {
+		t.Errorf("Background write error was not reported")
+	}
+}
+
+func TestAsyncQueueDropNewest(t *testing.T) {
+	q := &asyncQueue{policy: DropNewest, records: make(chan record, 1)}
+	q.enqueue(record{console: []byte("first")})
+	q.enqueue(record{console: []byte("second")})
+	if q.dropped != 1 {
+		t.Errorf("Dropped count does not match, expected '%v' got '%v'", 1, q.dropped)
+	}
+	if r := <-q.records; string(r.console) != "first" {
+		t.Errorf("Wrong entry kept, expected '%v' got '%v'", "first", string(r.console))
+	}
+}
+
+func TestAsyncQueueDropOldest(t *testing.T) {
+	q := &asyncQueue{policy: DropOldest, records: make(chan record, 1)}
+	q.enqueue(record{console: []byte("first")})
+	q.enqueue(record{console: []byte("second")})
+	if q.dropped != 1 {
+		t.Errorf("Dropped count does not match, expected '%v' got '%v'", 1, q.dropped)
+	}
+	if r := <-q.records; string(r.console) != "second" {
+		t.Errorf("Wrong entry kept, expected '%v' got '%v'", "second", string(r.console))
+	}
+
+	marker := record{flushed: make(chan struct{})}
+	q.records <- marker
+	q.enqueue(record{console: []byte("third")})
+	if r := <-q.records; r.flushed == nil {
+		t.Errorf("Flush marker was dropped")
+	}
+}
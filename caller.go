@@ -0,0 +1,110 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"errors"
+	"path/filepath"
+	"runtime"
+	"strconv"
+)
+
+// The CallerFormat type represents formatting flags for the caller annotation.
+type CallerFormat uint8
+
+// Caller format flags can be used in the format (fileflag|FuncName).
+// ShortFile and LongFile cannot be used together.
+const (
+	ShortFile  CallerFormat = 1 << iota //"event.go:12"
+	LongFile                            //"/home/user/project/event.go:12"
+	FuncName                            //"main.run"
+	callermask = ShortFile | LongFile
+	cfuncmask  = callermask | FuncName
+)
+
+// A callsite represents the source location an event was logged from.
+type callsite struct {
+	file     string
+	line     int
+	function string
+}
+
+// ShowCaller sets whether or not to annotate entries with their caller for the entire
+// logger. Callers are not shown by default.
+func (l *Logger) ShowCaller(b bool) {
+	l.caller = b
+}
+
+// SetCallerSkip sets the number of extra stack frames to skip when looking up the
+// caller. Use this when Log is called through your own wrapper functions.
+func (l *Logger) SetCallerSkip(skip int) error {
+	if skip < 0 {
+		return errors.New("Invalid caller skip depth")
+	}
+	l.callerSkip = skip
+	return nil
+}
+
+// ShowCaller sets whether or not to show the caller for this log event.
+func (e *Event) ShowCaller(b bool) {
+	e.caller = b
+}
+
+// SetCallerFormat sets the format flags for configuring the caller of the event.
+func (e *Event) SetCallerFormat(format CallerFormat) error {
+	if ok := validateCaller(format); !ok {
+		return errors.New("Invalid caller format")
+	}
+	e.callerFormat = format
+	return nil
+}
+
+// callsite looks up the caller depth frames above callsite itself, plus the logger's
+// caller skip. It returns nil if the caller is not shown for this event.
+func (e *Event) callsite(depth int) *callsite {
+	if !(e.Logger.caller && e.caller) {
+		return nil
+	}
+	pc, file, line, ok := runtime.Caller(depth + 1 + e.Logger.callerSkip)
+	if !ok {
+		return &callsite{"???", 0, ""}
+	}
+	cs := &callsite{file: file, line: line}
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		cs.function = fn.Name()
+	}
+	return cs
+}
+
+// appendFormat appends the callsite to dst using the given format flags.
+func (cs *callsite) appendFormat(dst []byte, format CallerFormat) []byte {
+	n := len(dst)
+	switch format & callermask {
+	case ShortFile:
+		dst = append(dst, filepath.Base(cs.file)...)
+	case LongFile:
+		dst = append(dst, cs.file...)
+	}
+	if len(dst) != n {
+		dst = append(dst, ':')
+		dst = strconv.AppendInt(dst, int64(cs.line), 10)
+	}
+	if (format&FuncName) == FuncName && cs.function != "" {
+		if len(dst) != n {
+			dst = append(dst, ' ')
+		}
+		dst = append(dst, filepath.Base(cs.function)...)
+	}
+	return dst
+}
+
+// validateCaller returns true if the given caller format is valid.
+func validateCaller(format CallerFormat) bool {
+	if format == 0 || (format|cfuncmask) != cfuncmask {
+		return false
+	}
+	return (format & callermask) != callermask
+}
@@ -0,0 +1,134 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/logrusorgru/aurora"
+)
+
+func TestLoggerShowCaller(t *testing.T) {
+	test := New()
+	if test.caller {
+		t.Errorf("Caller flag was not properly set, expected '%v' got '%v'", false, test.caller)
+	}
+
+	test.ShowCaller(true)
+	if !test.caller {
+		t.Errorf("Caller flag was not properly set, expected '%v' got '%v'", true, test.caller)
+	}
+}
+
+func TestLoggerSetCallerSkip(t *testing.T) {
+	test := New()
+	if err := test.SetCallerSkip(2); err != nil {
+		t.Errorf("Error setting caller skip: %v", err)
+	}
+	if test.callerSkip != 2 {
+		t.Errorf("Caller skip was not set, expected '%v' got '%v'", 2, test.callerSkip)
+	}
+	if err := test.SetCallerSkip(-1); err == nil {
+		t.Errorf("Negative caller skip did not trigger error")
+	}
+}
+
+func TestEventSetCallerFormat(t *testing.T) {
+	test := New()
+	if err := test.Error.SetCallerFormat(LongFile | FuncName); err != nil {
+		t.Errorf("Error setting caller format: %v", err)
+	}
+	if test.Error.callerFormat != (LongFile | FuncName) {
+		t.Errorf("Caller format was not set, expected '%v' got '%v'", LongFile|FuncName, test.Error.callerFormat)
+	}
+	if err := test.Error.SetCallerFormat(ShortFile | LongFile); err == nil {
+		t.Errorf("Invalid flag combination did not trigger error")
+	}
+	if err := test.Error.SetCallerFormat(0); err == nil {
+		t.Errorf("Empty caller format did not trigger error")
+	}
+}
+
+func TestEventLogCaller(t *testing.T) {
+	test := New(false, false)
+	test.ShowCaller(true)
+	message := "Test message"
+
+	res, err := test.Error.Log(message)
+	_, _, line, _ := runtime.Caller(0)
+	if err != nil {
+		t.Errorf("Error building string: %v", err)
+	}
+	expected := "ERROR: caller_test.go:" + strconv.Itoa(line-1) + " " + message
+	res = trimSpaces(res)
+	if res != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, res)
+	}
+
+	test.Error.SetCallerFormat(FuncName)
+	res, _ = test.Error.Log(message)
+	expected = "ERROR: logger.TestEventLogCaller " + message
+	res = trimSpaces(res)
+	if res != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, res)
+	}
+
+	test.Error.ShowCaller(false)
+	res, _ = test.Error.Log(message)
+	expected = "ERROR: " + message
+	res = trimSpaces(res)
+	if res != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, res)
+	}
+}
+
+func TestLoggerCallerSkip(t *testing.T) {
+	test := New(false, false)
+	test.ShowCaller(true)
+	test.SetCallerSkip(1)
+	test.Error.SetCallerFormat(FuncName)
+	wrapper := func() string {
+		res, _ := test.Error.Log("Test message")
+		return res
+	}
+
+	res := trimSpaces(wrapper())
+	expected := "ERROR: logger.TestLoggerCallerSkip Test message"
+	if res != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, res)
+	}
+}
+
+func TestEventCallerColor(t *testing.T) {
+	redfg := esc + aurora.RedFg.Nos() + "m"
+	test := New(false, true)
+	test.ShowCaller(true)
+	test.Error.SetCallerFormat(FuncName)
+	test.Error.SetColorFormat(Caller)
+	res, _ := test.Error.Log("Test message")
+	expected := "ERROR: " + redfg + "logger.TestEventCallerColor" + clear + " Test message"
+	res = trimSpaces(res)
+	if res != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, res)
+	}
+}
+
+func TestCallsiteFormat(t *testing.T) {
+	cs := &callsite{"/src/project/main.go", 12, "main.run"}
+	tests := map[CallerFormat]string{
+		ShortFile:            "main.go:12",
+		LongFile:             "/src/project/main.go:12",
+		FuncName:             "main.run",
+		ShortFile | FuncName: "main.go:12 main.run",
+	}
+	for format, expected := range tests {
+		if actual := string(cs.appendFormat(nil, format)); actual != expected {
+			t.Errorf("Caller doesn't match, expected '%v' got '%v'", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Field keys used for the error chain of an entry.
+const (
+	ErrorChainKey = "error.chain" // messages of every error in the chain
+	ErrorTypesKey = "error.types" // concrete type names of every error in the chain
+)
+
+// ShowErrorChain sets whether or not to render the full chain of the first error passed
+// to Log for this log event. Each error found through errors.Unwrap and errors.Join is
+// listed on its own line along with its concrete type.
+func (e *Event) ShowErrorChain(b bool) {
+	e.chain = b
+}
+
+// errorChain returns the error chain fields for the first error in a, or nil if error
+// chains are not shown for this event.
+func (e *Event) errorChain(a []interface{}) []Field {
+	if !e.chain {
+		return nil
+	}
+	for _, arg := range a {
+		if err, ok := arg.(error); ok && err != nil {
+			return errorChainFields(err)
+		}
+	}
+	return nil
+}
+
+// errorChainFields walks the chain of err depth first and returns its messages and
+// concrete type names as fields.
+func errorChainFields(err error) []Field {
+	var chain, types []string
+	walkErrors(err, 0, func(err error, depth int) {
+		chain = append(chain, errorText(err))
+		types = append(types, reflect.TypeOf(err).String())
+	})
+	return []Field{{ErrorChainKey, chain}, {ErrorTypesKey, types}}
+}
+
+// errorText returns the message of err. A nil pointer error is formatted by fmt.Sprint,
+// which prints "<nil>" when its Error method panics, as Log does for %v.
+func errorText(err error) string {
+	if isNilPointer(err) {
+		return fmt.Sprint(err)
+	}
+	return err.Error()
+}
+
+// isNilPointer returns true if v is a nil pointer, whose methods may panic.
+func isNilPointer(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// formatErrorChain renders error chain fields as indented lines in the same style as a
+// stack trace.
+func formatErrorChain(fields []Field) string {
+	var chain, types []string
+	for _, f := range fields {
+		switch f.Key {
+		case ErrorChainKey:
+			chain, _ = f.Value.([]string)
+		case ErrorTypesKey:
+			types, _ = f.Value.([]string)
+		}
+	}
+
+	var out string
+	for i, message := range chain {
+		line := ErrorChainKey + "[" + strconv.Itoa(i) + "]: " + message
+		if i < len(types) {
+			line = fmt.Sprintf("%v (%v)", line, types[i])
+		}
+		out += line + "\n"
+	}
+	return indentStack(out)
+}
@@ -0,0 +1,79 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestEventShowErrorChain(t *testing.T) {
+	test := New()
+	test.Error.ShowErrorChain(true)
+	if !test.Error.chain {
+		t.Errorf("Error chain flag was not properly set, expected '%v' got '%v'", true, test.Error.chain)
+	}
+
+	test.Error.ShowErrorChain(false)
+	if test.Error.chain {
+		t.Errorf("Error chain flag was not properly set, expected '%v' got '%v'", false, test.Error.chain)
+	}
+}
+
+func TestErrorChainFields(t *testing.T) {
+	inner := errors.New("inner")
+	other := errors.New("other")
+	err := fmt.Errorf("outer: %w", errors.Join(inner, other))
+
+	expected := []Field{
+		{ErrorChainKey, []string{"outer: inner\nother", "inner\nother", "inner", "other"}},
+		{ErrorTypesKey, []string{"*fmt.wrapError", "*errors.joinError", "*errors.errorString", "*errors.errorString"}},
+	}
+	actual := errorChainFields(err)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Error chain does not match, expected '%v' got '%v'", expected, actual)
+	}
+}
+
+func TestEventLogErrorChain(t *testing.T) {
+	test := New(false, false)
+	err := fmt.Errorf("outer: %w", errors.New("inner"))
+
+	res, _ := test.Error.Log("Failed: %v", err)
+	expected := "ERROR: Failed: outer: inner\n"
+	if res != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, res)
+	}
+
+	test.Error.ShowErrorChain(true)
+	res, _ = test.Error.Log("Failed: %v", err)
+	expected = "ERROR: Failed: outer: inner\n" +
+		stackIndent + "error.chain[0]: outer: inner (*fmt.wrapError)\n" +
+		stackIndent + "error.chain[1]: inner (*errors.errorString)\n"
+	if res != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, res)
+	}
+
+	res, _ = test.Error.Log("No error here")
+	expected = "ERROR: No error here\n"
+	if res != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, res)
+	}
+}
+
+func TestEventLogNilErrorChain(t *testing.T) {
+	test := New(false, false)
+	test.Error.ShowErrorChain(true)
+	var err *stackError
+	res, _ := test.Error.Log("Failed: %v", err)
+	expected := "ERROR: Failed: <nil>\n" +
+		stackIndent + "error.chain[0]: <nil> (*logger.stackError)\n"
+	if res != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, res)
+	}
+}
@@ -0,0 +1,72 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"strconv"
+	"time"
+)
+
+// A Clock represents the source of the time used for timestamps.
+type Clock interface {
+	Now() time.Time
+}
+
+// The ClockFunc type is an adapter to allow the use of ordinary functions as a Clock.
+type ClockFunc func() time.Time
+
+// Now returns f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// wallClock is the default Clock, it returns the current local time.
+type wallClock struct{}
+
+// Now returns time.Now().
+func (wallClock) Now() time.Time {
+	return time.Now()
+}
+
+// SetClock sets the clock used for timestamps and restarts the elapsed time of the
+// logger. A nil clock restores the wall clock.
+func (l *Logger) SetClock(c Clock) {
+	if c == nil {
+		c = wallClock{}
+	}
+	l.clock = c
+	l.start = c.Now()
+	for _, e := range l.events() {
+		e.last.Store(0)
+	}
+}
+
+// ShowElapsed sets whether or not to show the time elapsed since the logger was created
+// after the timestamp of every event, such as "+1.234s".
+func (l *Logger) ShowElapsed(b bool) {
+	l.elapsed = b
+	l.resetWidths()
+}
+
+// Elapsed returns the time elapsed since the logger was created, or since the clock was
+// last set.
+func (l *Logger) Elapsed() time.Duration {
+	return l.now().Sub(l.start)
+}
+
+// now returns the current time of the logger's clock.
+func (l *Logger) now() time.Time {
+	return l.clock.Now()
+}
+
+// appendElapsed appends an elapsed duration to dst in seconds, such as "+1.234s".
+func appendElapsed(dst []byte, d time.Duration) []byte {
+	if d >= 0 {
+		dst = append(dst, '+')
+	}
+	dst = strconv.AppendFloat(dst, d.Seconds(), 'f', 3, 64)
+	return append(dst, 's')
+}
@@ -0,0 +1,112 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/logrusorgru/aurora"
+)
+
+// stepClock returns a clock that starts at start and moves forward by step every time
+// it is read.
+func stepClock(start time.Time, step time.Duration) Clock {
+	now := start.Add(-step)
+	return ClockFunc(func() time.Time {
+		now = now.Add(step)
+		return now
+	})
+}
+
+func TestLoggerSetClock(t *testing.T) {
+	test := New()
+	fixed := time.Date(2018, 2, 5, 14, 3, 4, 0, time.UTC)
+	test.SetClock(ClockFunc(func() time.Time { return fixed }))
+	if !test.now().Equal(fixed) {
+		t.Errorf("Clock was not set, expected '%v' got '%v'", fixed, test.now())
+	}
+	if !test.start.Equal(fixed) {
+		t.Errorf("Start time was not reset, expected '%v' got '%v'", fixed, test.start)
+	}
+
+	test.SetClock(nil)
+	if _, ok := test.clock.(wallClock); !ok {
+		t.Errorf("Nil clock did not restore the wall clock")
+	}
+}
+
+func TestLoggerElapsed(t *testing.T) {
+	test := New(false, false)
+	test.SetClock(stepClock(time.Date(2018, 2, 5, 14, 3, 4, 0, time.UTC), 1500*time.Millisecond))
+	if d := test.Elapsed(); d != 1500*time.Millisecond {
+		t.Errorf("Elapsed time doesn't match, expected '%v' got '%v'", 1500*time.Millisecond, d)
+	}
+}
+
+func TestLoggerShowElapsed(t *testing.T) {
+	test := New(true, false)
+	test.SetClock(stepClock(time.Date(2018, 2, 5, 14, 3, 4, 0, time.UTC), 1234*time.Millisecond))
+	test.SetLocation(time.UTC)
+	test.Error.SetFormat(Time24Hour)
+	test.ShowElapsed(true)
+
+	res, err := test.Error.Log("Test message")
+	if err != nil {
+		t.Errorf("Error building string: %v", err)
+	}
+	expected := "14:03:05 +1.234s - ERROR: Test message"
+	res = trimSpaces(res)
+	if res != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, res)
+	}
+
+	res, _ = test.Error.Log("Test message")
+	expected = "14:03:06 +2.468s - ERROR: Test message"
+	res = trimSpaces(res)
+	if res != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, res)
+	}
+}
+
+func TestEventElapsedLayouts(t *testing.T) {
+	test := New(true, false)
+	test.SetClock(stepClock(time.Date(2018, 2, 5, 14, 3, 4, 0, time.UTC), 250*time.Millisecond))
+	test.SetLayout(Elapsed)
+	test.Notice.SetLayout(SincePrevious)
+
+	expected := []string{
+		"+0.250s - ERROR: Test message",
+		"+0.500s - NOTICE: Test message",
+		"+0.750s - ERROR: Test message",
+		"+0.500s - NOTICE: Test message",
+	}
+	var actual []string
+	for i := 0; i < 2; i++ {
+		res, _ := test.Error.Log("Test message")
+		actual = append(actual, trimSpaces(res))
+		res, _ = test.Notice.Log("Test message")
+		actual = append(actual, trimSpaces(res))
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Strings do not match, expected '%v' got '%v'", expected[i], actual[i])
+		}
+	}
+}
+
+func TestEventElapsedColor(t *testing.T) {
+	test := New(true, true)
+	test.SetClock(stepClock(time.Date(2018, 2, 5, 14, 3, 4, 0, time.UTC), time.Second))
+	test.Error.SetLayout(Elapsed)
+	test.Error.SetColorFormat(Timestamp)
+	res, _ := test.Error.Log("Test message")
+	expected := esc + aurora.RedFg.Nos() + "m+1.000s" + clear + " - ERROR: Test message"
+	res = trimSpaces(res)
+	if res != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, res)
+	}
+}
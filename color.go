@@ -0,0 +1,402 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// A Color represents the colors and special formats of a log event. Its basic colors and
+// formats have the values of the aurora colors, so an aurora.Color converts with
+// Color(c). It is 64 bits wide on every platform to hold the 256 color palette and
+// 24-bit RGB colors.
+type Color uint64
+
+// The ColorLevel type represents how many colors an output can show.
+type ColorLevel uint8
+
+// Constants for defining ColorLevels.
+const (
+	BasicColor ColorLevel = iota // The 8 basic colors.
+	Color256                     // The 256 color palette.
+	TrueColor                    // 24-bit RGB colors.
+)
+
+// Additional formats, these can be combined with the aurora special formats.
+const (
+	Faint Color = 4 << iota
+	Italic
+	Underline
+)
+
+// Extended colors are stored in the bits of a Color that aurora does not use. A
+// foreground or background nibble of 0xf marks an extended color, which is either an
+// index into the 256 color palette or an RGB value. The foreground value is kept in bits
+// 20-43. The background value does not fit in the remaining bits, so its low nibble is
+// kept in bits 12-15 and the rest in bits 44-63.
+const (
+	fgmask   = 0xf << 8
+	bgmask   = 0xf << 16
+	fgrgb    = 1 << 5
+	bgrgb    = 1 << 6
+	fgvalue  = 0xffffff << 20
+	bglow    = 0xf << 12
+	bghigh   = 0xfffff << 44
+	extended = 0xf
+)
+
+// basicRGB holds the RGB values of the basic colors, in the order of the aurora colors.
+var basicRGB = [8][3]uint8{
+	{0, 0, 0},
+	{205, 0, 0},
+	{0, 205, 0},
+	{205, 205, 0},
+	{0, 0, 238},
+	{205, 0, 205},
+	{0, 205, 205},
+	{229, 229, 229},
+}
+
+// brightRGB holds the RGB values of the bright colors, 8-15 in the 256 color palette.
+var brightRGB = [8][3]uint8{
+	{127, 127, 127},
+	{255, 0, 0},
+	{0, 255, 0},
+	{255, 255, 0},
+	{92, 92, 255},
+	{255, 0, 255},
+	{0, 255, 255},
+	{255, 255, 255},
+}
+
+// cubeLevels holds the component values of the 6x6x6 color cube in the 256 color palette.
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// Fg256 returns the foreground color at index n of the 256 color palette.
+func Fg256(n uint8) Color {
+	return fgColor(uint64(n), false)
+}
+
+// Bg256 returns the background color at index n of the 256 color palette.
+func Bg256(n uint8) Color {
+	return bgColor(uint64(n), false)
+}
+
+// FgRGB returns the 24-bit foreground color with the given red, green and blue values.
+func FgRGB(r, g, b uint8) Color {
+	return fgColor(packRGB(r, g, b), true)
+}
+
+// BgRGB returns the 24-bit background color with the given red, green and blue values.
+func BgRGB(r, g, b uint8) Color {
+	return bgColor(packRGB(r, g, b), true)
+}
+
+// FgHex returns the 24-bit foreground color for a hex string such as "#ff8800" or "f80".
+func FgHex(hex string) (Color, error) {
+	r, g, b, err := parseHex(hex)
+	if err != nil {
+		return 0, err
+	}
+	return FgRGB(r, g, b), nil
+}
+
+// BgHex returns the 24-bit background color for a hex string such as "#ff8800" or "f80".
+func BgHex(hex string) (Color, error) {
+	r, g, b, err := parseHex(hex)
+	if err != nil {
+		return 0, err
+	}
+	return BgRGB(r, g, b), nil
+}
+
+// SetColorLevel sets how many colors the stderr can show, overriding the level detected
+// from the environment. Colors the stderr cannot show are replaced by the nearest color
+// it can show.
+func (l *Logger) SetColorLevel(level ColorLevel) error {
+	if level > TrueColor {
+		return errors.New("Invalid color level")
+	}
+	l.colorLevel = level
+	return nil
+}
+
+// shownColors returns the colors of the event, reduced to what the stderr can show.
+func (e *Event) shownColors() Color {
+	return reduceColor(e.colors, e.Logger.colorLevel)
+}
+
+// detectColorLevel returns the color level of the terminal from the COLORTERM and TERM
+// environment variables.
+func detectColorLevel() ColorLevel {
+	switch os.Getenv("COLORTERM") {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+	if strings.Contains(os.Getenv("TERM"), "256color") {
+		return Color256
+	}
+	return BasicColor
+}
+
+// reduceColor replaces the extended colors in c that cannot be shown at the given level
+// with the nearest color that can.
+func reduceColor(c Color, level ColorLevel) Color {
+	if level == TrueColor {
+		return c
+	}
+	u := uint64(c)
+	if fg, rgb, ok := fgOf(u); ok {
+		u &^= fgmask | fgrgb | fgvalue
+		switch {
+		case level == BasicColor:
+			u |= uint64(nearestBasic(fg, rgb)+1) << 8
+		case rgb:
+			u |= uint64(fgColor(rgbTo256(fg), false))
+		default:
+			u |= uint64(fgColor(fg, false))
+		}
+	}
+	if bg, rgb, ok := bgOf(u); ok {
+		u &^= bgmask | bgrgb | bglow | bghigh
+		switch {
+		case level == BasicColor:
+			u |= uint64(nearestBasic(bg, rgb)+1) << 16
+		case rgb:
+			u |= uint64(bgColor(rgbTo256(bg), false))
+		default:
+			u |= uint64(bgColor(bg, false))
+		}
+	}
+	return Color(u)
+}
+
+// appendColored appends s to dst, wrapped in the escape sequences for the given colors
+// when colored is true.
+func appendColored(dst []byte, s string, c Color, colored bool) []byte {
+	if !colored {
+		return append(dst, s...)
+	}
+	dst = appendColorStart(dst, c)
+	dst = append(dst, s...)
+	return appendColorEnd(dst, c)
+}
+
+// appendColorStart appends the escape sequence that turns on the given colors. For the
+// basic colors it produces the same sequence as aurora.Colorize without allocating.
+func appendColorStart(dst []byte, c Color) []byte {
+	if c == 0 {
+		return dst
+	}
+	dst = append(dst, "\033["...)
+	start := len(dst)
+	if c&Bold != 0 {
+		dst = appendCode(dst, start, '1')
+	}
+	if c&Faint != 0 {
+		dst = appendCode(dst, start, '2')
+	}
+	if c&Italic != 0 {
+		dst = appendCode(dst, start, '3')
+	}
+	if c&Underline != 0 {
+		dst = appendCode(dst, start, '4')
+	}
+	if c&Inverse != 0 {
+		dst = appendCode(dst, start, '7')
+	}
+
+	u := uint64(c)
+	if fg, rgb, ok := fgOf(u); ok {
+		dst = appendExtended(dst, start, '3', fg, rgb)
+	} else if fg := (u & fgmask) >> 8; fg != 0 {
+		dst = appendCode(dst, start, '3', byte('0'+fg-1))
+	}
+	if bg, rgb, ok := bgOf(u); ok {
+		dst = appendExtended(dst, start, '4', bg, rgb)
+	} else if bg := (u & bgmask) >> 16; bg != 0 {
+		dst = appendCode(dst, start, '4', byte('0'+bg-1))
+	}
+	return append(dst, 'm')
+}
+
+// appendExtended appends the SGR parameters of an extended color, where kind is '3' for
+// a foreground and '4' for a background.
+func appendExtended(dst []byte, start int, kind byte, v uint64, rgb bool) []byte {
+	if !rgb {
+		dst = appendCode(dst, start, kind, '8', ';', '5', ';')
+		return strconv.AppendUint(dst, v, 10)
+	}
+	dst = appendCode(dst, start, kind, '8', ';', '2', ';')
+	dst = strconv.AppendUint(dst, v>>16, 10)
+	dst = append(dst, ';')
+	dst = strconv.AppendUint(dst, (v>>8)&0xff, 10)
+	dst = append(dst, ';')
+	return strconv.AppendUint(dst, v&0xff, 10)
+}
+
+// appendCode appends a single SGR parameter, separated from the parameters written
+// since start.
+func appendCode(dst []byte, start int, code ...byte) []byte {
+	if len(dst) != start {
+		dst = append(dst, ';')
+	}
+	return append(dst, code...)
+}
+
+// appendColorEnd appends the escape sequence that resets the colors turned on by
+// appendColorStart.
+func appendColorEnd(dst []byte, c Color) []byte {
+	if c == 0 {
+		return dst
+	}
+	return append(dst, "\033[0m"...)
+}
+
+// fgColor returns an extended foreground color holding v.
+func fgColor(v uint64, rgb bool) Color {
+	u := uint64(extended)<<8 | v<<20
+	if rgb {
+		u |= fgrgb
+	}
+	return Color(u)
+}
+
+// bgColor returns an extended background color holding v.
+func bgColor(v uint64, rgb bool) Color {
+	u := uint64(extended)<<16 | (v&0xf)<<12 | (v>>4)<<44
+	if rgb {
+		u |= bgrgb
+	}
+	return Color(u)
+}
+
+// fgOf returns the value of the extended foreground color in u.
+func fgOf(u uint64) (v uint64, rgb bool, ok bool) {
+	if (u&fgmask)>>8 != extended {
+		return 0, false, false
+	}
+	return (u & fgvalue) >> 20, u&fgrgb != 0, true
+}
+
+// bgOf returns the value of the extended background color in u.
+func bgOf(u uint64) (v uint64, rgb bool, ok bool) {
+	if (u&bgmask)>>16 != extended {
+		return 0, false, false
+	}
+	return (u&bghigh)>>44<<4 | (u&bglow)>>12, u&bgrgb != 0, true
+}
+
+// packRGB packs red, green and blue values into 24 bits.
+func packRGB(r, g, b uint8) uint64 {
+	return uint64(r)<<16 | uint64(g)<<8 | uint64(b)
+}
+
+// parseHex parses a hex color string such as "#ff8800" or "f80".
+func parseHex(hex string) (r, g, b uint8, err error) {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return 0, 0, 0, errors.New("Invalid hex color")
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, 0, 0, errors.New("Invalid hex color")
+	}
+	return uint8(v >> 16), uint8(v >> 8), uint8(v), nil
+}
+
+// paletteRGB returns the RGB value of index n in the 256 color palette.
+func paletteRGB(n uint64) [3]uint8 {
+	switch {
+	case n < 8:
+		return basicRGB[n]
+	case n < 16:
+		return brightRGB[n-8]
+	case n < 232:
+		n -= 16
+		return [3]uint8{cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]}
+	default:
+		g := uint8(8 + 10*(n-232))
+		return [3]uint8{g, g, g}
+	}
+}
+
+// rgbTo256 returns the index of the 256 color palette closest to a packed RGB value.
+func rgbTo256(v uint64) uint64 {
+	c := [3]uint8{uint8(v >> 16), uint8(v >> 8), uint8(v)}
+	var cube uint64
+	for _, x := range c {
+		cube = cube*6 + nearestLevel(x)
+	}
+	cube += 16
+
+	avg := (int(c[0]) + int(c[1]) + int(c[2])) / 3
+	gray := uint64(232)
+	if avg > 8 {
+		gray += uint64((avg - 3) / 10)
+	}
+	if gray > 255 {
+		gray = 255
+	}
+
+	if distance(paletteRGB(gray), c) < distance(paletteRGB(cube), c) {
+		return gray
+	}
+	return cube
+}
+
+// nearestLevel returns the index of the color cube level closest to x.
+func nearestLevel(x uint8) uint64 {
+	best := uint64(0)
+	for i, level := range cubeLevels {
+		if abs(int(level)-int(x)) < abs(int(cubeLevels[best])-int(x)) {
+			best = uint64(i)
+		}
+	}
+	return best
+}
+
+// nearestBasic returns the index of the basic color closest to an extended color value.
+func nearestBasic(v uint64, rgb bool) uint64 {
+	if !rgb && v < 16 {
+		return v % 8
+	}
+	c := paletteRGB(v)
+	if rgb {
+		c = [3]uint8{uint8(v >> 16), uint8(v >> 8), uint8(v)}
+	}
+	best := uint64(0)
+	for i := range basicRGB {
+		if distance(basicRGB[i], c) < distance(basicRGB[best], c) {
+			best = uint64(i)
+		}
+	}
+	return best
+}
+
+// distance returns the squared distance between two RGB colors.
+func distance(a, b [3]uint8) int {
+	var d int
+	for i := range a {
+		x := int(a[i]) - int(b[i])
+		d += x * x
+	}
+	return d
+}
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
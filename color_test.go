@@ -0,0 +1,114 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"testing"
+
+	"github.com/logrusorgru/aurora"
+)
+
+func TestAppendColorStartExtended(t *testing.T) {
+	tests := map[Color]string{
+		Fg256(208):                               esc + "38;5;208m",
+		Bg256(17):                                esc + "48;5;17m",
+		FgRGB(255, 136, 0):                       esc + "38;2;255;136;0m",
+		BgRGB(1, 2, 3):                           esc + "48;2;1;2;3m",
+		FgRGB(10, 20, 30) | BgRGB(250, 240, 230): esc + "38;2;10;20;30;48;2;250;240;230m",
+		Bold | Underline | Fg256(1):              esc + "1;4;38;5;1m",
+		Faint | Italic | RedFg:                   esc + "2;3;31m",
+		Fg256(99) | RedFg:                        esc + "38;5;99m",
+	}
+	for c, expected := range tests {
+		actual := string(appendColorStart(nil, c))
+		if actual != expected {
+			t.Errorf("Color codes do not match, expected '%q' got '%q'", expected, actual)
+		}
+	}
+}
+
+func TestHexColors(t *testing.T) {
+	c, err := FgHex("#ff8800")
+	if err != nil {
+		t.Errorf("Error parsing hex color: %v", err)
+	}
+	if c != FgRGB(255, 136, 0) {
+		t.Errorf("Hex color doesn't match, expected '%v' got '%v'", FgRGB(255, 136, 0), c)
+	}
+	c, err = BgHex("f80")
+	if err != nil {
+		t.Errorf("Error parsing hex color: %v", err)
+	}
+	if c != BgRGB(255, 136, 0) {
+		t.Errorf("Hex color doesn't match, expected '%v' got '%v'", BgRGB(255, 136, 0), c)
+	}
+	for _, bad := range []string{"", "#ff88", "zzzzzz", "#ff880011"} {
+		if _, err := FgHex(bad); err == nil {
+			t.Errorf("Invalid hex color '%v' did not trigger error", bad)
+		}
+	}
+}
+
+func TestReduceColor(t *testing.T) {
+	tests := []struct {
+		color    Color
+		level    ColorLevel
+		expected Color
+	}{
+		{FgRGB(250, 10, 10), TrueColor, FgRGB(250, 10, 10)},
+		{FgRGB(255, 0, 0), Color256, Fg256(196)},
+		{BgRGB(128, 128, 128), Color256, Bg256(244)},
+		{FgRGB(250, 10, 10) | Bold, BasicColor, RedFg | Bold},
+		{BgRGB(10, 10, 240), BasicColor, BlueBg},
+		{Fg256(10), BasicColor, GreenFg},
+		{Fg256(226) | Bg256(16), BasicColor, YellowFg | BlackBg},
+		{GrayFg | MagentaBg, BasicColor, GrayFg | MagentaBg},
+	}
+	for _, test := range tests {
+		actual := reduceColor(test.color, test.level)
+		if actual != test.expected {
+			t.Errorf("Reduced color doesn't match, expected '%q' got '%q'",
+				appendColorStart(nil, test.expected), appendColorStart(nil, actual))
+		}
+	}
+}
+
+func TestLoggerSetColorLevel(t *testing.T) {
+	test := New(false, true)
+	if err := test.SetColorLevel(TrueColor + 1); err == nil {
+		t.Errorf("Invalid color level did not trigger error")
+	}
+	test.Error.SetColors(FgRGB(250, 10, 10))
+	test.SetColorLevel(BasicColor)
+	res, _ := test.Error.Log("Test message")
+	expected := esc + aurora.RedFg.Nos() + "mERROR:" + clear + " Test message"
+	if actual := trimSpaces(res); actual != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, actual)
+	}
+
+	test.SetColorLevel(TrueColor)
+	res, _ = test.Error.Log("Test message")
+	expected = esc + "38;2;250;10;10mERROR:" + clear + " Test message"
+	if actual := trimSpaces(res); actual != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, actual)
+	}
+}
+
+func TestDetectColorLevel(t *testing.T) {
+	t.Setenv("COLORTERM", "truecolor")
+	if level := detectColorLevel(); level != TrueColor {
+		t.Errorf("Color level doesn't match, expected '%v' got '%v'", TrueColor, level)
+	}
+	t.Setenv("COLORTERM", "")
+	t.Setenv("TERM", "xterm-256color")
+	if level := detectColorLevel(); level != Color256 {
+		t.Errorf("Color level doesn't match, expected '%v' got '%v'", Color256, level)
+	}
+	t.Setenv("TERM", "xterm")
+	if level := detectColorLevel(); level != BasicColor {
+		t.Errorf("Color level doesn't match, expected '%v' got '%v'", BasicColor, level)
+	}
+}
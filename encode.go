@@ -0,0 +1,117 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// An Encoder appends an encoded entry to dst. Encoders are used by the sinks that send
+// entries over the network.
+type Encoder func(dst []byte, en *Entry) []byte
+
+// jsonReserved are the keys of EncodeJSON that fields may not use.
+var jsonReserved = map[string]bool{
+	"time":     true,
+	"level":    true,
+	"msg":      true,
+	"caller":   true,
+	"function": true,
+	"stack":    true,
+}
+
+// EncodeJSON encodes an entry as a single line JSON object, such as
+// {"time":"2018-02-05T14:03:04Z","level":"error","msg":"Failed","id":7}. Fields are
+// placed next to the message, fields named like one of the other keys get a "fields."
+// prefix.
+func EncodeJSON(dst []byte, en *Entry) []byte {
+	dst = append(dst, `{"time":`...)
+	dst = appendJSONString(dst, en.Time.Format(time.RFC3339Nano))
+	dst = append(dst, `,"level":`...)
+	dst = appendJSONString(dst, levelName(en.Level))
+	dst = append(dst, `,"msg":`...)
+	dst = appendJSONString(dst, en.Message)
+	if en.File != "" {
+		dst = append(dst, `,"caller":`...)
+		dst = appendJSONString(dst, en.File+":"+strconv.Itoa(en.Line))
+		if en.Function != "" {
+			dst = append(dst, `,"function":`...)
+			dst = appendJSONString(dst, en.Function)
+		}
+	}
+	for _, f := range en.Fields {
+		key := f.Key
+		if jsonReserved[key] {
+			key = "fields." + key
+		}
+		dst = append(dst, ',')
+		dst = appendJSONString(dst, key)
+		dst = append(dst, ':')
+		dst = appendJSONValue(dst, f.Value)
+	}
+	if en.Stack != "" {
+		dst = append(dst, `,"stack":`...)
+		dst = appendJSONString(dst, en.Stack)
+	}
+	return append(dst, '}')
+}
+
+// EncodeText encodes an entry as a line of text without colors, such as
+// "2018-02-05T14:03:04.000Z ERROR: Failed id=7". Stack traces are left out.
+func EncodeText(dst []byte, en *Entry) []byte {
+	dst = en.Time.AppendFormat(dst, ISO8601Milli)
+	dst = append(dst, ' ')
+	dst = append(dst, en.Prefix...)
+	if en.File != "" {
+		dst = append(dst, ' ')
+		dst = append(dst, en.File...)
+		dst = append(dst, ':')
+		dst = strconv.AppendInt(dst, int64(en.Line), 10)
+	}
+	dst = append(dst, ' ')
+	dst = append(dst, en.Message...)
+	return appendFields(dst, en.Fields)
+}
+
+// levelName returns the lowercase name of the event with the given level.
+func levelName(level LogLevel) string {
+	switch level {
+	case All:
+		return "debug"
+	case Verbose:
+		return "info"
+	case Normal:
+		return "notice"
+	}
+	return "error"
+}
+
+// appendJSONString appends s to dst as a JSON string.
+func appendJSONString(dst []byte, s string) []byte {
+	b, _ := json.Marshal(s)
+	return append(dst, b...)
+}
+
+// appendJSONValue appends a field value to dst as JSON. Errors and values with a String
+// method are encoded as strings, values that cannot be encoded are formatted with
+// fmt.Sprint.
+func appendJSONValue(dst []byte, v interface{}) []byte {
+	switch x := v.(type) {
+	case error:
+		return appendJSONString(dst, errorText(x))
+	case json.Marshaler:
+	case fmt.Stringer:
+		return appendJSONString(dst, stringerText(x))
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return appendJSONString(dst, fmt.Sprint(v))
+	}
+	return append(dst, b...)
+}
@@ -0,0 +1,54 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestEncodeJSON(t *testing.T) {
+	en := &Entry{
+		Time:     time.Date(2018, 2, 5, 14, 3, 4, 500, time.UTC),
+		Level:    ErrorsOnly,
+		Prefix:   "ERROR:",
+		Message:  `Failed "x"`,
+		Fields:   []Field{{"id", 7}, {"took", 1500 * time.Millisecond}, {"err", errors.New("boom")}, {"msg", "dup"}, {"ch", make(chan int)}},
+		File:     "/src/main.go",
+		Line:     3,
+		Function: "main.main",
+		Stack:    "    main.main\n",
+	}
+	expected := `{"time":"2018-02-05T14:03:04.0000005Z","level":"error","msg":"Failed \"x\"","caller":"/src/main.go:3",` +
+		`"function":"main.main","id":7,"took":"1.5s","err":"boom","fields.msg":"dup","ch":"` + fmt.Sprint(en.Fields[4].Value) + `",` +
+		`"stack":"    main.main\n"}`
+	actual := string(EncodeJSON(nil, en))
+	if actual != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, actual)
+	}
+	if !json.Valid([]byte(actual)) {
+		t.Errorf("Encoded entry is not valid JSON")
+	}
+}
+
+func TestEncodeText(t *testing.T) {
+	en := &Entry{
+		Time:    time.Date(2018, 2, 5, 14, 3, 4, 0, time.UTC),
+		Level:   All,
+		Prefix:  "DEBUG:",
+		Message: "Query",
+		Fields:  []Field{{"rows", 3}},
+		File:    "main.go",
+		Line:    9,
+	}
+	expected := "2018-02-05T14:03:04.000Z DEBUG: main.go:9 Query rows=3"
+	if actual := string(EncodeText(nil, en)); actual != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, actual)
+	}
+}
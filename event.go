@@ -9,28 +9,45 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"strings"
-	"text/tabwriter"
+	"strconv"
+	"sync/atomic"
 	"time"
-
-	"github.com/logrusorgru/aurora"
 )
 
 // An Event represents a message with a given level of importance to be printed to the
 // log.
 type Event struct {
-	*Logger   // a Pointer to the parent Logger
-	timestamp bool
-	colored   bool
-	colors    aurora.Color
-	format    int
-	cformat   ColorFormat
-	prefix    string
+	*Logger      // a Pointer to the parent Logger
+	timestamp    bool
+	colored      bool
+	colors       Color
+	format       int
+	cformat      ColorFormat
+	prefix       string
+	caller       bool
+	callerFormat CallerFormat
+	stack        bool
+	chain        bool
+	layout       string
+	template     *template
+	last         atomic.Int64 // time of the previous entry, relative to the logger start
+}
+
+// An entry represents a single call to Log, captured before it is rendered.
+type entry struct {
+	time    time.Time
+	caller  *callsite
+	fields  []Field
+	fstring string
+	args    []interface{}
+	trace   TraceContext
+	since   time.Duration // time since the previous entry of the event
 }
 
 // ShowTimestamp sets whether or not to show timestamps for this log event.
 func (e *Event) ShowTimestamp(b bool) {
 	e.timestamp = b
+	e.Logger.resetWidths()
 }
 
 // ShowColor sets wether or not to show color for this log event.
@@ -39,20 +56,35 @@ func (e *Event) ShowColor(b bool) {
 }
 
 // SetColors sets the foreground color, background color, and special format of the log event
-func (e *Event) SetColors(colors aurora.Color) {
+func (e *Event) SetColors(colors Color) {
 	e.colors = colors
 }
 
-// SetFormat sets the format flags for configuring the timestamp of the event
+// SetFormat sets the format flags for configuring the timestamp of the event. It replaces
+// any layout set with SetLayout.
 func (e *Event) SetFormat(format int) error {
 	if ok := validateTimestamp(format); !ok {
 		return errors.New("Invalid format flag combination")
 	}
 	e.format = format
+	e.layout = ""
+	e.Logger.resetWidths()
+	return nil
+}
+
+// SetLayout sets a Go time layout, such as time.Kitchen or one of the preset layouts, for
+// the timestamp of the event. It is used instead of the format flags until SetFormat is
+// called or the layout is set to an empty string.
+func (e *Event) SetLayout(layout string) error {
+	if ok := validateLayout(layout); !ok {
+		return errors.New("Invalid timestamp layout")
+	}
+	e.layout = layout
+	e.Logger.resetWidths()
 	return nil
 }
 
-// SetColorFormat sets the format for the colored output. Timestamp adds color to the timestamp. Prefix adds color to the Prefix. Message adds color to the Message.
+// SetColorFormat sets the format for the colored output. Timestamp adds color to the timestamp. Prefix adds color to the Prefix. Message adds color to the Message. Caller adds color to the caller. Highlight colors the keys, values, numbers, quoted strings, durations and errors in the message and fields with the token colors of the Logger.
 func (e *Event) SetColorFormat(format ColorFormat) error {
 	if (format | cformatMask) != cformatMask {
 		return errors.New("Invalid color format")
@@ -66,167 +98,321 @@ func (e *Event) Prefix() string {
 	return e.prefix
 }
 
-// Log logs the given message via the appropriate log event to STDERR. It will not
-// display any log event that is lower than the given level. Debug will not show when
-// the log level is Normal.
-func (e *Event) Log(fstring string, a ...interface{}) (string, error) {
-	var entry string
-	var err error
+// Level returns the highest log level at which the log event is shown, All for Debug,
+// Verbose for Info, Normal for Notice and ErrorsOnly for Error.
+func (e *Event) Level() LogLevel {
 	switch e.Prefix() {
 	case "DEBUG:":
-		if e.Logger.LogLevel() == All {
-			entry, err = e.printf(fstring, a...)
-			if err != nil {
-				return entry, err
-			}
-		}
+		return All
 	case "INFO:":
-		if e.Logger.LogLevel() <= Verbose {
-			entry, err = e.printf(fstring, a...)
-			if err != nil {
-				return entry, err
-			}
-		}
+		return Verbose
 	case "NOTICE:":
-		if e.Logger.LogLevel() <= Normal {
-			entry, err = e.printf(fstring, a...)
-			if err != nil {
-				return entry, err
-			}
-		}
-	case "ERROR:":
-		if e.Logger.LogLevel() <= ErrorsOnly {
-			entry, err = e.printf(fstring, a...)
-			if err != nil {
-				return entry, err
-			}
-		}
+		return Normal
 	}
+	return ErrorsOnly
+}
 
-	return entry, nil
+// Enabled returns true if the log event is shown at the current log level, or by an
+// output with a lower level. Use it to skip computing expensive arguments to Log. Debug is
+// not enabled when the log level is Normal.
+func (e *Event) Enabled() bool {
+	return e.Logger.minLevel() <= e.Level()
 }
 
-// buildMessage constructs a message using the given input and format code.
-func (e *Event) buildMessage(message string) (string, error) {
-	timestamp, err := e.buildTimestamp()
-	if err != nil {
-		return "", err
+// Log logs the given message via the appropriate log event to STDERR. It will not
+// display any log event that is lower than the given level. Debug will not show when
+// the log level is Normal. It returns the line written to the stderr, or an empty
+// string if the entry is not shown there.
+func (e *Event) Log(fstring string, a ...interface{}) (string, error) {
+	if !e.Enabled() {
+		return "", nil
 	}
+	return e.printf(TraceContext{}, nil, fstring, a...)
+}
 
-	prefix := e.Prefix()
-	if e.colored && e.Logger.colored {
-		if (e.cformat & Prefix) == Prefix {
-			prefix = fmt.Sprint(aurora.Colorize(prefix, e.colors))
-		}
+// LogFunc logs the message returned by fn. fn is only called when the log event is
+// enabled, so it can build messages that are expensive to compute.
+func (e *Event) LogFunc(fn func() string) (string, error) {
+	if !e.Enabled() {
+		return "", nil
+	}
+	return e.printf(TraceContext{}, nil, "%s", fn())
+}
 
-		if (e.cformat & Message) == Message {
-			message = fmt.Sprint(aurora.Colorize(message, e.colors))
-		}
+// LogFields logs the given message along with the fields. Fields are shown after the
+// message as key=value.
+func (e *Event) LogFields(message string, fields ...Field) (string, error) {
+	if !e.Enabled() {
+		return "", nil
 	}
-	var fmessage string
-	if e.Logger.timestamp && e.timestamp {
-		fmessage = timestamp + " - " + prefix + " " + message
-	} else {
-		fmessage = prefix + " " + message
+	return e.printf(TraceContext{}, fields, "%s", message)
+}
+
+// LogFieldsFunc logs the given message along with the fields returned by fn. fn is only
+// called when the log event is enabled. Fields are shown after the message as key=value.
+func (e *Event) LogFieldsFunc(message string, fn func() []Field) (string, error) {
+	if !e.Enabled() {
+		return "", nil
 	}
-	return fmessage + "\t\n", nil
+	return e.printf(TraceContext{}, fn(), "%s", message)
 }
 
-// buildTimestamp constructs the timestamp using the format flags of the event.
-func (e *Event) buildTimestamp() (string, error) {
-	var datestamp, timestamp, zone string
-	var words []string
+// appendEntry appends the entry rendered in the style of an output to dst. The short
+// trace ID and the caller are placed after the prefix when the entry has them. Events
+// with a template are rendered by their template instead.
+func (e *Event) appendEntry(dst []byte, en *entry, st OutputStyle) ([]byte, error) {
+	if e.template != nil {
+		return e.template.appendEntry(dst, e, en, st)
+	}
+	colored := e.colorFor(st)
 	if e.Logger.timestamp && e.timestamp {
-		if ok := validateTimestamp(e.format); !ok {
-			return "", errors.New("Invalid date flags")
-		}
-		t := time.Now()
-		if (e.format & datemask) == ShortDate {
-			datestamp = t.Format("1/2/2006")
-		} else if (e.format & datemask) == LongDate {
-			datestamp = t.Format("2 Jan 2006")
-		}
-		if datestamp != "" {
-			words = append(words, datestamp)
+		var err error
+		if dst, err = e.appendTimestamp(dst, en, st); err != nil {
+			return dst, err
 		}
+		dst = append(dst, " - "...)
+	}
 
-		if (e.format & hourmask) == Time12Hour {
-			timestamp = t.Format("3:04:05 PM")
-		} else if (e.format & hourmask) == Time24Hour {
-			timestamp = t.Format("15:04:05")
-		}
-		if timestamp != "" {
-			words = append(words, timestamp)
+	dst = e.appendPrefix(dst, colored && (e.cformat&Prefix) == Prefix)
+	if en.trace.TraceID != "" {
+		dst = append(dst, " ["...)
+		dst = appendShortTrace(dst, en)
+		dst = append(dst, ']')
+	}
+	if en.caller != nil {
+		mark := len(dst)
+		dst = append(dst, ' ')
+		n := len(dst)
+		if dst = e.appendCaller(dst, en, colored); len(dst) == n {
+			dst = dst[:mark]
 		}
+	}
 
-		if (e.format & TimeZone) == TimeZone {
-			zone = t.Format("MST")
-		}
+	dst = append(dst, ' ')
+	dst = e.appendMessage(dst, en, colored)
+	dst = e.appendFields(dst, en, colored)
+	return append(dst, '\n'), nil
+}
+
+// appendCaller appends the caller of the entry, if it has one.
+func (e *Event) appendCaller(dst []byte, en *entry, colored bool) []byte {
+	if en.caller == nil {
+		return dst
+	}
+	mark := len(dst)
+	colors := e.shownColors()
+	ccolored := colored && (e.cformat&Caller) == Caller
+	if ccolored {
+		dst = appendColorStart(dst, colors)
+	}
+	n := len(dst)
+	dst = en.caller.appendFormat(dst, e.callerFormat)
+	if len(dst) == n {
+		return dst[:mark]
+	}
+	if ccolored {
+		dst = appendColorEnd(dst, colors)
+	}
+	return dst
+}
 
-		if zone != "" {
-			words = append(words, zone)
+// appendMessage appends the formatted message of the entry.
+func (e *Event) appendMessage(dst []byte, en *entry, colored bool) []byte {
+	colors := e.shownColors()
+	mcolored := colored && (e.cformat&Message) == Message
+	if mcolored {
+		dst = appendColorStart(dst, colors)
+	}
+	if colored && (e.cformat&Highlight) == Highlight {
+		var base Color
+		if mcolored {
+			base = colors
 		}
+		h := e.highlighter(en, base)
+		msg := getBuffer()
+		*msg = fmt.Appendf(*msg, en.fstring, en.args...)
+		dst = h.appendMessage(dst, *msg)
+		putBuffer(msg)
+	} else {
+		dst = fmt.Appendf(dst, en.fstring, en.args...)
 	}
-	fstamp := strings.Join(words, " ")
-	if (e.cformat&Timestamp) == Timestamp &&
-		e.colored && e.Logger.colored {
-		fstamp = fmt.Sprint(aurora.Colorize(fstamp, e.colors))
+	if mcolored {
+		dst = appendColorEnd(dst, colors)
 	}
-	return fstamp + "\t", nil
+	return dst
 }
 
-// prints a message to the stderr
-func (e *Event) printf(fstring string, a ...interface{}) (string, error) {
-	var spacing int
-	message, err := e.buildMessage(fstring)
+// appendFields appends the fields of the entry as space separated key=value pairs.
+func (e *Event) appendFields(dst []byte, en *entry, colored bool) []byte {
+	if colored && (e.cformat&Highlight) == Highlight {
+		h := highlighter{tokens: e.Logger.tokens, level: e.Logger.colorLevel}
+		return h.appendFields(dst, en.fields)
+	}
+	return appendFields(dst, en.fields)
+}
+
+// appendTimestamp appends the timestamp of the entry in the style of an output, padded to
+// the width of the timestamp column.
+func (e *Event) appendTimestamp(dst []byte, en *entry, st OutputStyle) ([]byte, error) {
+	mark := len(dst)
+	dst, err := e.appendTimestampText(dst, en, st)
 	if err != nil {
-		return "", err
+		return dst, err
 	}
+	width := e.Logger.timestampWidth(e.Logger.locationOf(en.time), st)
+	return appendPadding(dst, width-visibleWidth(dst[mark:])), nil
+}
 
-	spacing = e.setSpacing()
-	w := tabwriter.NewWriter(os.Stderr, spacing, 0, 0, ' ', 0)
-	fmt.Fprint(w, fmt.Sprintf(message, a...))
-	w.Flush()
-	if e.Logger.toDisk {
-		if err = e.writeToFile(fstring, a...); err != nil {
-			return "", err
-		}
+// appendTimestampText appends the timestamp of the entry without padding.
+func (e *Event) appendTimestampText(dst []byte, en *entry, st OutputStyle) ([]byte, error) {
+	format, layout := e.timeSettings(st)
+	if ok := validateTimestamp(format); !ok && layout == "" {
+		return dst, errors.New("Invalid date flags")
+	}
+	lt := en.time.In(e.Logger.locationOf(en.time))
+	elapsed := en.time.Sub(e.Logger.start)
+
+	colored := (e.cformat&Timestamp) == Timestamp && e.colorFor(st)
+	colors := e.shownColors()
+	if colored {
+		dst = appendColorStart(dst, colors)
+	}
+	dst = e.appendTime(dst, lt, elapsed, en.since, format, layout)
+	if colored {
+		dst = appendColorEnd(dst, colors)
 	}
-	return fmt.Sprintf(message, a...), nil
+	return dst, nil
 }
 
-func (e *Event) writeToFile(fstring string, a ...interface{}) error {
-	var temp bool
+// appendTime appends the timestamp text for t using the format flags, or the layout when
+// it is set. elapsed is the time since the logger started and since the time since the
+// previous entry of the event.
+func (e *Event) appendTime(dst []byte, t time.Time, elapsed, since time.Duration, format int, layout string) []byte {
+	switch layout {
+	case "":
+		dst = appendFlagTimestamp(dst, t, format)
+	case UnixSeconds:
+		dst = strconv.AppendInt(dst, t.Unix(), 10)
+	case UnixMillis:
+		dst = strconv.AppendInt(dst, t.UnixNano()/int64(time.Millisecond), 10)
+	case Elapsed:
+		dst = appendElapsed(dst, elapsed)
+	case SincePrevious:
+		dst = appendElapsed(dst, since)
+	default:
+		dst = t.AppendFormat(dst, layout)
+	}
+	if e.Logger.elapsed {
+		dst = append(dst, ' ')
+		dst = appendElapsed(dst, elapsed)
+	}
+	return dst
+}
 
-	f, err := os.OpenFile(e.Logger.logPath, os.O_WRONLY|os.O_APPEND, 0666)
-	if err != nil {
-		return err
+// appendFlagTimestamp appends the timestamp for t using format flags.
+func appendFlagTimestamp(dst []byte, t time.Time, format int) []byte {
+	n := len(dst)
+	if (format & datemask) == ShortDate {
+		dst = t.AppendFormat(dst, "1/2/2006")
+	} else if (format & datemask) == LongDate {
+		dst = t.AppendFormat(dst, "2 Jan 2006")
 	}
-	defer f.Close()
-	if e.colored && e.Logger.colored {
-		temp = true
-		e.ShowColor(false)
+
+	if (format & hourmask) != 0 {
+		if len(dst) != n {
+			dst = append(dst, ' ')
+		}
+		if (format & hourmask) == Time12Hour {
+			dst = t.AppendFormat(dst, "3:04:05 PM")
+		} else {
+			dst = t.AppendFormat(dst, "15:04:05")
+		}
 	}
 
-	message, err := e.buildMessage(fstring)
-	if err != nil {
-		return err
+	if (format & TimeZone) == TimeZone {
+		if len(dst) != n {
+			dst = append(dst, ' ')
+		}
+		dst = t.AppendFormat(dst, "MST")
 	}
-	if temp {
-		e.ShowColor(true)
+	return dst
+}
+
+// prints a message to the stderr
+func (e *Event) printf(tc TraceContext, fields []Field, fstring string, a ...interface{}) (string, error) {
+	en := entry{e.Logger.now(), e.callsite(2), fields, fstring, a, tc, 0}
+	elapsed := int64(en.time.Sub(e.Logger.start))
+	en.since = time.Duration(elapsed - e.last.Swap(elapsed))
+	chain := e.errorChain(a)
+	stack := e.stackTrace(2, a)
+	detail := formatErrorChain(chain) + stack
+
+	console := getBuffer()
+	defer putBuffer(console)
+	var err error
+	if *console, err = e.appendEntry(*console, &en, e.Logger.terminalStyle()); err != nil {
+		return "", err
 	}
-	message = fmt.Sprintf(message, a...)
-	f.WriteAt([]byte(message), 1)
+	*console = append(*console, detail...)
 
-	return nil
+	var trace []Field
+	if tc.TraceID != "" && e.Logger.routes != nil {
+		trace = tc.Fields()
+	}
+	r := record{console: *console, outputs: e.Logger.outputs(e.Level(), fields, trace, chain)}
+	if e.Logger.toDisk && r.outputs&fileBit != 0 {
+		file := getBuffer()
+		defer putBuffer(file)
+		if *file, err = e.appendEntry(*file, &en, e.Logger.fileStyle); err != nil {
+			return "", err
+		}
+		*file = append(*file, detail...)
+		r.path = e.Logger.logPath
+		r.file = *file
+	}
+	if e.Logger.sinkRouted(r.outputs) {
+		r.entry = e.newEntry(&en, chain, stack)
+		r.sinks = e.Logger.sinks
+	}
+	if err = e.Logger.output(r); err != nil {
+		return "", err
+	}
+	if r.outputs&stderrBit == 0 {
+		return "", nil
+	}
+	return string(r.console), nil
 }
 
-func (e *Event) setSpacing() int {
-	if (e.cformat & Timestamp) == Timestamp {
-		return 34
+// write prints a rendered record to the stderr, appends it to the save log and passes its
+// entry to the sinks, as far as they are in its output set. It returns the first error.
+func (l *Logger) write(r record) error {
+	if r.outputs&stderrBit != 0 {
+		os.Stderr.Write(r.console)
+	}
+	var first error
+	if r.path != "" {
+		first = appendFile(r.path, r.file)
+	}
+	if r.entry != nil {
+		for i, s := range r.sinks {
+			if !l.routed(r.outputs, i) {
+				continue
+			}
+			if err := s.Write(r.entry); err != nil && first == nil {
+				first = err
+			}
+		}
 	}
-	if (e.cformat & Prefix) == Prefix {
-		return 25
+	return first
+}
+
+// appendFile appends b to the file at path.
+func appendFile(path string, b []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return err
 	}
-	return 0
+	defer f.Close()
+	_, err = f.Write(b)
+	return err
 }
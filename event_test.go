@@ -174,14 +174,16 @@ func TestEventLog(t *testing.T) {
 	grayfg := esc + aurora.GrayFg.Nos() + "m"
 	yellowfg := esc + aurora.BrownFg.Nos() + "m"
 	redfg := esc + aurora.RedFg.Nos() + "m"
-	test := New()
+	test := New(true, true)
+	now := time.Date(2018, 2, 5, 14, 3, 4, 0, time.Local)
+	test.SetClock(ClockFunc(func() time.Time { return now }))
 	message := "Test message"
 	test.SetLogLevel(All)
 	res, err := test.Debug.Log(message)
 	if err != nil {
 		t.Errorf("Error building string: %v", err)
 	}
-	tn := time.Now()
+	tn := now
 	tf := tn.Format("1/2/2006 3:04:05 PM MST")
 	expected := tf + " - " + greenfg + test.Debug.Prefix() + clear + " " + message
 	res = trimSpaces(res)
@@ -206,7 +208,7 @@ func TestEventLog(t *testing.T) {
 	if err != nil {
 		t.Errorf("Error building string: %v", err)
 	}
-	tn = time.Now()
+	tn = now
 	tf = tn.Format("15:04:05 MST")
 	expected = grayfg + tf + clear + " - " + test.Info.Prefix() + " " + message
 	res = trimSpaces(res)
@@ -222,7 +224,7 @@ func TestEventLog(t *testing.T) {
 	if err != nil {
 		t.Errorf("Error building string: %v", err)
 	}
-	tn = time.Now()
+	tn = now
 	tf = tn.Format("2 Jan 2006 MST")
 	expected = yellowfg + tf + clear + " - " + yellowfg + test.Notice.Prefix() + clear + " " + message
 	res = trimSpaces(res)
@@ -238,7 +240,7 @@ func TestEventLog(t *testing.T) {
 	if err != nil {
 		t.Errorf("Error building string: %v", err)
 	}
-	tn = time.Now()
+	tn = now
 	tf = tn.Format("2 Jan 2006 3:04:05 PM MST")
 	expected = tf + " - " + test.Error.Prefix() + " " + redfg + message + clear
 	res = trimSpaces(res)
@@ -254,7 +256,7 @@ func TestEventLog(t *testing.T) {
 	if err != nil {
 		t.Errorf("Error building string: %v", err)
 	}
-	tn = time.Now()
+	tn = now
 	tf = tn.Format("1/2/2006 3:04:05 PM MST")
 	expected = tf + " - " + greenfg + test.Debug.Prefix() + clear + " " + greenfg + message + clear
 	res = trimSpaces(res)
@@ -269,7 +271,7 @@ func TestEventLog(t *testing.T) {
 	if err != nil {
 		t.Errorf("Error building string: %v", err)
 	}
-	tn = time.Now()
+	tn = now
 	tf = tn.Format("1/2/2006 3:04:05 PM MST")
 	expected = redfg + tf + clear + " - " + redfg + test.Error.Prefix() + clear + " " + redfg + message + clear
 	res = trimSpaces(res)
@@ -284,7 +286,7 @@ func TestEventLog(t *testing.T) {
 	if err != nil {
 		t.Errorf("Error building string: %v", err)
 	}
-	tn = time.Now()
+	tn = now
 	tf = tn.Format("1/2/2006 3:04:05 PM MST")
 	expected = grayfg + tf + clear + " - " + test.Info.Prefix() + " " + grayfg + message + clear
 	res = trimSpaces(res)
@@ -298,7 +300,7 @@ func TestEventLog(t *testing.T) {
 	if err != nil {
 		t.Errorf("Error building string: %v", err)
 	}
-	tn = time.Now()
+	tn = now
 	tf = tn.Format("1/2/2006 3:04:05 PM MST")
 	expected = tf + " - " + test.Debug.Prefix() + " " + message
 	res = trimSpaces(res)
@@ -328,22 +330,22 @@ func TestEventLog(t *testing.T) {
 
 func TestEventBuildMessage(t *testing.T) {
 	greenfg := esc + aurora.GreenFg.Nos() + "m"
-	test := New()
+	test := New(true, true)
 	now := time.Now()
 	timeformat := now.Format("1/2/2006 3:04:05 PM MST")
 	expected := timeformat + " - " + greenfg + "DEBUG:" + clear + " Test event"
-	actual, err := test.Debug.buildMessage("Test event")
+	b, err := test.Debug.appendEntry(nil, &entry{time: now, fstring: "Test event"}, OutputStyle{})
 	if err != nil {
 		t.Errorf("Error building message: %v", err)
 	}
-	actual = trimSpaces(actual)
+	actual := trimSpaces(string(b))
 	expected = trimSpaces(expected)
 	if actual != expected {
 		t.Errorf("Messages don't match, expected '%v' got '%v'", expected, actual)
 	}
 
 	test.Debug.format = ShortDate | LongDate
-	_, err = test.Debug.buildMessage("Test event")
+	_, err = test.Debug.appendEntry(nil, &entry{time: now, fstring: "Test event"}, OutputStyle{})
 	if err == nil {
 		t.Errorf("Bad input did not trigger error")
 	}
@@ -428,11 +430,11 @@ func TestEventBuildTimestamp(t *testing.T) {
 	now := time.Now()
 	expectedf := now.Format("1/2/2006")
 	test.Debug.SetFormat(ShortDate)
-	actualf, err := test.Debug.buildTimestamp()
+	b, err := test.Debug.appendTimestamp(nil, &entry{time: now}, OutputStyle{})
 	if err != nil {
 		t.Errorf("Error building timestamp: %v", err)
 	}
-	actualf = trimSpaces(actualf)
+	actualf := trimSpaces(string(b))
 
 	if actualf != expectedf {
 		t.Errorf("Date doesn't match, expected '%v' got '%v'", expectedf, actualf)
@@ -441,11 +443,11 @@ func TestEventBuildTimestamp(t *testing.T) {
 	now = time.Now()
 	expectedf = now.Format("2 Jan 2006")
 	test.Debug.SetFormat(LongDate)
-	actualf, err = test.Debug.buildTimestamp()
+	b, err = test.Debug.appendTimestamp(nil, &entry{time: now}, OutputStyle{})
 	if err != nil {
 		t.Errorf("Error building timestamp: %v", err)
 	}
-	actualf = trimSpaces(actualf)
+	actualf = trimSpaces(string(b))
 	if actualf != expectedf {
 		t.Errorf("Date doesn't match, expected '%v' got '%v'", expectedf, actualf)
 	}
@@ -453,11 +455,11 @@ func TestEventBuildTimestamp(t *testing.T) {
 	now = time.Now()
 	expectedf = now.Format("3:04:05 PM")
 	test.Debug.SetFormat(Time12Hour)
-	actualf, err = test.Debug.buildTimestamp()
+	b, err = test.Debug.appendTimestamp(nil, &entry{time: now}, OutputStyle{})
 	if err != nil {
 		t.Errorf("Error building timestamp: %v", err)
 	}
-	actualf = trimSpaces(actualf)
+	actualf = trimSpaces(string(b))
 	if actualf != expectedf {
 		t.Errorf("Date doesn't match, expected '%v' got '%v'", expectedf, actualf)
 	}
@@ -465,11 +467,11 @@ func TestEventBuildTimestamp(t *testing.T) {
 	now = time.Now()
 	expectedf = now.Format("15:04:05")
 	test.Debug.SetFormat(Time24Hour)
-	actualf, err = test.Debug.buildTimestamp()
+	b, err = test.Debug.appendTimestamp(nil, &entry{time: now}, OutputStyle{})
 	if err != nil {
 		t.Errorf("Error building timestamp: %v", err)
 	}
-	actualf = trimSpaces(actualf)
+	actualf = trimSpaces(string(b))
 	if actualf != expectedf {
 		t.Errorf("Date doesn't match, expected '%v' got '%v'", expectedf, actualf)
 	}
@@ -477,20 +479,135 @@ func TestEventBuildTimestamp(t *testing.T) {
 	now = time.Now()
 	expectedf = now.Format("3:04:05 PM MST")
 	test.Debug.SetFormat(Time12Hour | TimeZone)
-	actualf, err = test.Debug.buildTimestamp()
+	b, err = test.Debug.appendTimestamp(nil, &entry{time: now}, OutputStyle{})
 	if err != nil {
 		t.Errorf("Error building timestamp: %v", err)
 	}
-	actualf = trimSpaces(actualf)
+	actualf = trimSpaces(string(b))
 	if actualf != expectedf {
 		t.Errorf("Date doesn't match, expected '%v' got '%v'", expectedf, actualf)
 	}
 
 	now = time.Now()
 	test.Debug.format = (ShortDate | LongDate)
-	_, err = test.Debug.buildTimestamp()
+	_, err = test.Debug.appendTimestamp(nil, &entry{time: now}, OutputStyle{})
 	if err == nil {
 		t.Errorf("Bad input did not trigger error")
 	}
 
 }
+
+func TestEventEnabled(t *testing.T) {
+	test := New()
+	test.SetLogLevel(Normal)
+	if test.Debug.Enabled() || test.Info.Enabled() {
+		t.Errorf("Events below the log level are enabled")
+	}
+	if !test.Notice.Enabled() || !test.Error.Enabled() {
+		t.Errorf("Events at or above the log level are not enabled")
+	}
+
+	test.SetLogLevel(Test)
+	if test.Error.Enabled() {
+		t.Errorf("Error event enabled at log level '%v'", Test)
+	}
+}
+
+func TestEventLogFunc(t *testing.T) {
+	test := New(false, false)
+	called := false
+	fn := func() string {
+		called = true
+		return "100% lazy"
+	}
+
+	test.SetLogLevel(Normal)
+	res, err := test.Debug.LogFunc(fn)
+	if err != nil {
+		t.Errorf("Error building string: %v", err)
+	}
+	if called || res != "" {
+		t.Errorf("Message function called for disabled event")
+	}
+
+	res, err = test.Error.LogFunc(fn)
+	if err != nil {
+		t.Errorf("Error building string: %v", err)
+	}
+	expected := "ERROR: 100% lazy"
+	res = trimSpaces(res)
+	if res != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, res)
+	}
+}
+
+func TestEventLogFieldsFunc(t *testing.T) {
+	test := New(false, false)
+	called := false
+	fn := func() []Field {
+		called = true
+		return []Field{{"component", "db"}, {"query", "SELECT 1"}, {"rows", 3}}
+	}
+
+	test.Debug.LogFieldsFunc("Test message", fn)
+	if called {
+		t.Errorf("Fields function called for disabled event")
+	}
+
+	res, err := test.Error.LogFieldsFunc("Test message", fn)
+	if err != nil {
+		t.Errorf("Error building string: %v", err)
+	}
+	expected := `ERROR: Test message component=db query="SELECT 1" rows=3`
+	res = trimSpaces(res)
+	if res != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, res)
+	}
+}
+
+func TestEventSetLayout(t *testing.T) {
+	test := New()
+	if err := test.Debug.SetLayout(time.Kitchen); err != nil {
+		t.Errorf("Error setting layout: %v", err)
+	}
+	if test.Debug.layout != time.Kitchen {
+		t.Errorf("Layout was not set, expected '%v' got '%v'", time.Kitchen, test.Debug.layout)
+	}
+	if err := test.Debug.SetLayout("no time here"); err == nil {
+		t.Errorf("Invalid layout did not trigger error")
+	}
+
+	test.Debug.SetFormat(LongDate)
+	if test.Debug.layout != "" {
+		t.Errorf("SetFormat did not clear the layout, got '%v'", test.Debug.layout)
+	}
+}
+
+func TestEventLayoutTimestamp(t *testing.T) {
+	test := New()
+	now := time.Date(2018, 2, 5, 14, 3, 4, 5006007, time.UTC)
+	test.SetLocation(time.UTC)
+	layouts := map[string]string{
+		RFC3339:      "2018-02-05T14:03:04Z",
+		RFC3339Nano:  "2018-02-05T14:03:04.005006007Z",
+		ISO8601Milli: "2018-02-05T14:03:04.005Z",
+		UnixSeconds:  "1517839384",
+		UnixMillis:   "1517839384005",
+		time.Kitchen: "2:03PM",
+	}
+	for layout, expected := range layouts {
+		test.Debug.SetLayout(layout)
+		b, err := test.Debug.appendTimestamp(nil, &entry{time: now}, OutputStyle{})
+		if err != nil {
+			t.Errorf("Error building timestamp: %v", err)
+		}
+		if actual := trimSpaces(string(b)); actual != expected {
+			t.Errorf("Date doesn't match, expected '%v' got '%v'", expected, actual)
+		}
+	}
+
+	test.Debug.format = ShortDate | LongDate
+	if _, err := test.Debug.appendTimestamp(nil, &entry{time: now}, OutputStyle{}); err != nil {
+		t.Errorf("Format flags were checked while using a layout: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"errors"
+	"os"
+	"sync"
+)
+
+// A FileSink represents a file that encoded entries are appended to, one per line.
+type FileSink struct {
+	mu     sync.Mutex
+	encode Encoder
+	file   *os.File
+}
+
+// NewFileSink opens or creates the file at path and appends entries to it, encoded with
+// enc. A nil encoder encodes entries with EncodeText.
+func NewFileSink(path string, enc Encoder) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
+	if err != nil {
+		return nil, err
+	}
+	if enc == nil {
+		enc = EncodeText
+	}
+	return &FileSink{encode: enc, file: f}, nil
+}
+
+// Write appends an entry to the file.
+func (s *FileSink) Write(en *Entry) error {
+	b := getBuffer()
+	defer putBuffer(b)
+	*b = append(s.encode(*b, en), '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return errors.New("File sink is closed")
+	}
+	_, err := s.file.Write(*b)
+	return err
+}
+
+// Close closes the file.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
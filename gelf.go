@@ -0,0 +1,311 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The Compression type represents how GELF messages sent over UDP are compressed.
+type Compression uint8
+
+// Compression modes of a GELFSink.
+const (
+	NoCompression Compression = iota
+	ZlibCompression
+	GzipCompression
+)
+
+// GELF chunking limits.
+const (
+	defaultChunkSize = 1420
+	minChunkSize     = 128
+	maxChunkSize     = 65467
+	maxChunks        = 128
+	chunkHeaderSize  = 12
+)
+
+// gelfTimeout is the dial and write timeout of a GELFSink.
+const gelfTimeout = time.Second
+
+// GELFEncoder returns an encoder for GELF 1.1 messages from the given host. Levels are
+// sent as syslog severities, the prefix as the _prefix field, the caller as _file, _line
+// and _function, the stack trace as the full message, and fields as additional fields
+// with a "_" in front of their names. Field values other than numbers are sent as strings.
+func GELFEncoder(host string) Encoder {
+	return func(dst []byte, en *Entry) []byte {
+		dst = append(dst, `{"version":"1.1","host":`...)
+		dst = appendJSONString(dst, host)
+		dst = append(dst, `,"short_message":`...)
+		dst = appendJSONString(dst, en.Message)
+		if en.Stack != "" {
+			dst = append(dst, `,"full_message":`...)
+			dst = appendJSONString(dst, en.Stack)
+		}
+		dst = append(dst, `,"timestamp":`...)
+		dst = strconv.AppendFloat(dst, float64(en.Time.UnixNano()/int64(time.Millisecond))/1000, 'f', 3, 64)
+		dst = append(dst, `,"level":`...)
+		dst = strconv.AppendInt(dst, int64(syslogSeverity(en.Level)), 10)
+		dst = append(dst, `,"_prefix":`...)
+		dst = appendJSONString(dst, en.Prefix)
+		if en.File != "" {
+			dst = append(dst, `,"_file":`...)
+			dst = appendJSONString(dst, en.File)
+			dst = append(dst, `,"_line":`...)
+			dst = strconv.AppendInt(dst, int64(en.Line), 10)
+			if en.Function != "" {
+				dst = append(dst, `,"_function":`...)
+				dst = appendJSONString(dst, en.Function)
+			}
+		}
+		for _, f := range en.Fields {
+			dst = append(dst, ',')
+			dst = appendJSONString(dst, gelfName(f.Key))
+			dst = append(dst, ':')
+			dst = appendGELFValue(dst, f.Value)
+		}
+		return append(dst, '}')
+	}
+}
+
+// appendGELFValue appends the value of an additional field. GELF only allows strings and
+// numbers, so other values are sent as text and lists such as the error chain are
+// joined by newlines.
+func appendGELFValue(dst []byte, v interface{}) []byte {
+	switch x := v.(type) {
+	case []string:
+		return appendJSONString(dst, strings.Join(x, "\n"))
+	case bool:
+		return appendJSONString(dst, strconv.FormatBool(x))
+	}
+	mark := len(dst)
+	dst = appendJSONValue(dst, v)
+	switch c := dst[mark]; {
+	case c == '"', c == '-', c >= '0' && c <= '9':
+		return dst
+	}
+	// an array, object, boolean or null, such as a value of a json.Marshaler
+	raw := string(dst[mark:])
+	return appendJSONString(dst[:mark], raw)
+}
+
+// gelfName returns the name of the additional field for key. Names may only contain
+// letters, digits, underscores, dashes and dots, and _id is reserved.
+func gelfName(key string) string {
+	b := append([]byte{'_'}, key...)
+	for i, c := range b {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-' || c == '.') {
+			b[i] = '_'
+		}
+	}
+	if string(b) == "_id" {
+		return "_id_"
+	}
+	return string(b)
+}
+
+// A GELFSink represents a connection to a GELF server, such as Graylog.
+type GELFSink struct {
+	mu          sync.Mutex
+	network     string
+	address     string
+	encode      Encoder
+	compression Compression
+	chunkSize   int
+	conn        net.Conn
+}
+
+// NewGELFSink connects to the GELF input at address. network is "udp" or "tcp". Messages
+// sent over TCP are terminated by a null byte, messages sent over UDP are split into
+// chunks when they are larger than the chunk size.
+func NewGELFSink(network, address string) (*GELFSink, error) {
+	switch network {
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6":
+	default:
+		return nil, errors.New("Invalid GELF network: " + network)
+	}
+	host, _ := os.Hostname()
+	s := &GELFSink{
+		network:   network,
+		address:   address,
+		encode:    GELFEncoder(host),
+		chunkSize: defaultChunkSize,
+	}
+	if err := s.connect(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SetHost sets the host sent with every message, the name of this machine by default.
+func (s *GELFSink) SetHost(host string) {
+	s.mu.Lock()
+	s.encode = GELFEncoder(host)
+	s.mu.Unlock()
+}
+
+// SetCompression sets the compression of messages. GELF over TCP does not support
+// compression.
+func (s *GELFSink) SetCompression(c Compression) error {
+	if c > GzipCompression {
+		return errors.New("Invalid compression")
+	}
+	if c != NoCompression && !s.udp() {
+		return errors.New("Compression is only supported over UDP")
+	}
+	s.mu.Lock()
+	s.compression = c
+	s.mu.Unlock()
+	return nil
+}
+
+// SetChunkSize sets the largest UDP datagram sent, including the chunk header. It is 1420
+// by default, which fits in most networks.
+func (s *GELFSink) SetChunkSize(n int) error {
+	if n < minChunkSize || n > maxChunkSize {
+		return errors.New("Invalid chunk size")
+	}
+	s.mu.Lock()
+	s.chunkSize = n
+	s.mu.Unlock()
+	return nil
+}
+
+// Write sends an entry to the GELF server. If the connection was lost, Write reconnects
+// once and sends the entry again.
+func (s *GELFSink) Write(en *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	msg := s.encode(nil, en)
+	if s.udp() {
+		var err error
+		if msg, err = s.compress(msg); err != nil {
+			return err
+		}
+	} else {
+		msg = append(msg, 0)
+	}
+	if s.conn != nil {
+		if err := s.send(msg); err == nil {
+			return nil
+		}
+		s.conn.Close()
+		s.conn = nil
+	}
+	if err := s.connect(); err != nil {
+		return err
+	}
+	return s.send(msg)
+}
+
+// Close closes the connection to the GELF server.
+func (s *GELFSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// udp returns true if messages are sent over UDP.
+func (s *GELFSink) udp() bool {
+	switch s.network {
+	case "udp", "udp4", "udp6":
+		return true
+	}
+	return false
+}
+
+// connect opens the connection to the GELF server.
+func (s *GELFSink) connect() error {
+	conn, err := net.DialTimeout(s.network, s.address, gelfTimeout)
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	return nil
+}
+
+// send writes a message, split into chunks if it is too large for a single datagram.
+func (s *GELFSink) send(msg []byte) error {
+	s.conn.SetWriteDeadline(time.Now().Add(gelfTimeout))
+	if !s.udp() || len(msg) <= s.chunkSize {
+		_, err := s.conn.Write(msg)
+		return err
+	}
+
+	chunks := chunkMessage(msg, s.chunkSize, rand.Uint64())
+	if chunks == nil {
+		return errors.New("GELF message is too large: " + strconv.Itoa(len(msg)) + " bytes")
+	}
+	for _, chunk := range chunks {
+		if _, err := s.conn.Write(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compress returns msg compressed with the compression of the sink.
+func (s *GELFSink) compress(msg []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch s.compression {
+	case ZlibCompression:
+		w = zlib.NewWriter(&buf)
+	case GzipCompression:
+		w = gzip.NewWriter(&buf)
+	default:
+		return msg, nil
+	}
+	if _, err := w.Write(msg); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// chunkMessage splits msg into GELF chunks of at most size bytes. Every chunk starts
+// with the magic bytes 0x1e 0x0f, the message id, its sequence number and the number of
+// chunks. It returns nil if msg needs more than 128 chunks.
+func chunkMessage(msg []byte, size int, id uint64) [][]byte {
+	payload := size - chunkHeaderSize
+	count := (len(msg) + payload - 1) / payload
+	if count > maxChunks {
+		return nil
+	}
+	chunks := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * payload
+		if end > len(msg) {
+			end = len(msg)
+		}
+		chunk := make([]byte, 0, chunkHeaderSize+end-i*payload)
+		chunk = append(chunk, 0x1e, 0x0f)
+		chunk = binary.BigEndian.AppendUint64(chunk, id)
+		chunk = append(chunk, byte(i), byte(count))
+		chunks = append(chunks, append(chunk, msg[i*payload:end]...))
+	}
+	return chunks
+}
@@ -0,0 +1,209 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGELFEncoder(t *testing.T) {
+	en := &Entry{
+		Time:    time.Date(2018, 2, 5, 14, 3, 4, 250000000, time.UTC),
+		Level:   Normal,
+		Prefix:  "NOTICE:",
+		Message: "Disk low",
+		Fields:  []Field{{"free", 12}, {"id", "a"}, {"disk name", "sda"}},
+		File:    "main.go",
+		Line:    7,
+		Stack:   "    main.main\n",
+	}
+	expected := `{"version":"1.1","host":"web1","short_message":"Disk low","full_message":"    main.main\n",` +
+		`"timestamp":1517839384.250,"level":5,"_prefix":"NOTICE:","_file":"main.go","_line":7,` +
+		`"_free":12,"_id_":"a","_disk_name":"sda"}`
+	actual := string(GELFEncoder("web1")(nil, en))
+	if actual != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, actual)
+	}
+	if !json.Valid([]byte(actual)) {
+		t.Errorf("Encoded message is not valid JSON")
+	}
+}
+
+func TestGELFFieldValues(t *testing.T) {
+	en := &Entry{
+		Time:    time.Date(2018, 2, 5, 14, 3, 4, 0, time.UTC),
+		Level:   ErrorsOnly,
+		Message: "Failed",
+		Fields: []Field{
+			{ErrorChainKey, []string{"a: b", "b"}},
+			{"ok", true},
+			{"m", map[string]int{"x": 1}},
+			{"ratio", 0.5},
+			{"raw", json.RawMessage(`{"x":1}`)},
+		},
+	}
+	actual := string(GELFEncoder("web1")(nil, en))
+	expected := `,"_error.chain":"a: b\nb","_ok":"true","_m":"{\"x\":1}","_ratio":0.5,"_raw":"{\"x\":1}"}`
+	if !strings.HasSuffix(actual, expected) {
+		t.Errorf("Field values do not match, expected suffix '%v' got '%v'", expected, actual)
+	}
+	if !json.Valid([]byte(actual)) {
+		t.Errorf("Encoded message is not valid JSON")
+	}
+}
+
+func TestChunkMessage(t *testing.T) {
+	msg := bytes.Repeat([]byte("x"), 300)
+	chunks := chunkMessage(msg, 140, 0x0102030405060708)
+	if len(chunks) != 3 {
+		t.Fatalf("Wrong number of chunks, expected '%v' got '%v'", 3, len(chunks))
+	}
+	var joined []byte
+	for i, chunk := range chunks {
+		header := []byte{0x1e, 0x0f, 1, 2, 3, 4, 5, 6, 7, 8, byte(i), 3}
+		if !bytes.Equal(chunk[:chunkHeaderSize], header) {
+			t.Errorf("Wrong header for chunk %v, got '%v'", i, chunk[:chunkHeaderSize])
+		}
+		if len(chunk) > 140 {
+			t.Errorf("Chunk %v is too large, got '%v' bytes", i, len(chunk))
+		}
+		joined = append(joined, chunk[chunkHeaderSize:]...)
+	}
+	if !bytes.Equal(joined, msg) {
+		t.Errorf("Chunks do not add up to the message")
+	}
+	if chunkMessage(bytes.Repeat([]byte("x"), 129*128), 140, 1) != nil {
+		t.Errorf("Message needing more than 128 chunks was chunked")
+	}
+}
+
+func TestGELFSinkUDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("UDP not available: %v", err)
+	}
+	defer pc.Close()
+
+	sink, err := NewGELFSink("udp", pc.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("Error creating GELF sink: %v", err)
+	}
+	defer sink.Close()
+	sink.SetHost("web1")
+	buf := make([]byte, 70000)
+	read := func() []byte {
+		pc.SetReadDeadline(time.Now().Add(2 * time.Second))
+		n, _, err := pc.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("Error reading datagram: %v", err)
+		}
+		return append([]byte(nil), buf[:n]...)
+	}
+
+	sink.SetCompression(ZlibCompression)
+	sink.Write(&Entry{Level: ErrorsOnly, Message: "zlib"})
+	zr, err := zlib.NewReader(bytes.NewReader(read()))
+	if err != nil {
+		t.Fatalf("Message is not zlib compressed: %v", err)
+	}
+	if b, _ := io.ReadAll(zr); !strings.Contains(string(b), `"short_message":"zlib","timestamp":`) || !strings.Contains(string(b), `"level":3`) {
+		t.Errorf("Unexpected message '%v'", string(b))
+	}
+
+	sink.SetCompression(GzipCompression)
+	sink.Write(&Entry{Message: "gzip"})
+	gr, err := gzip.NewReader(bytes.NewReader(read()))
+	if err != nil {
+		t.Fatalf("Message is not gzip compressed: %v", err)
+	}
+	if b, _ := io.ReadAll(gr); !strings.Contains(string(b), `"short_message":"gzip"`) {
+		t.Errorf("Unexpected message '%v'", string(b))
+	}
+
+	sink.SetCompression(NoCompression)
+	sink.SetChunkSize(minChunkSize)
+	long := strings.Repeat("y", 500)
+	sink.Write(&Entry{Message: long})
+	var joined []byte
+	for {
+		chunk := read()
+		if chunk[0] != 0x1e || chunk[1] != 0x0f {
+			t.Fatalf("Datagram is not a chunk")
+		}
+		joined = append(joined, chunk[chunkHeaderSize:]...)
+		if int(chunk[10]) == int(chunk[11])-1 {
+			break
+		}
+	}
+	if !strings.Contains(string(joined), long) {
+		t.Errorf("Chunks do not add up to the message")
+	}
+}
+
+func TestGELFSinkTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("TCP not available: %v", err)
+	}
+	defer ln.Close()
+	messages := make(chan string, 2)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			msg, err := r.ReadString(0)
+			if err != nil {
+				return
+			}
+			messages <- strings.TrimSuffix(msg, "\x00")
+		}
+	}()
+
+	sink, err := NewGELFSink("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("Error creating GELF sink: %v", err)
+	}
+	if err := sink.SetCompression(GzipCompression); err == nil {
+		t.Errorf("Compression over TCP did not trigger error")
+	}
+	test := New(false, false)
+	defer discardStderr(t)()
+	test.AddSink(sink)
+	test.Error.LogFieldsFunc("Failed", func() []Field { return []Field{{"code", 500}} })
+
+	select {
+	case msg := <-messages:
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(msg), &m); err != nil {
+			t.Fatalf("Message is not JSON: %v", err)
+		}
+		if m["short_message"] != "Failed" || m["level"] != 3.0 || m["_code"] != 500.0 || m["_prefix"] != "ERROR:" {
+			t.Errorf("Unexpected message '%v'", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Timed out waiting for GELF message")
+	}
+	test.Close(time.Second)
+}
+
+func TestNewGELFSink(t *testing.T) {
+	if _, err := NewGELFSink("unix", "/dev/null"); err == nil {
+		t.Errorf("Invalid network did not trigger error")
+	}
+}
@@ -0,0 +1,317 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"bytes"
+	"time"
+)
+
+// TokenColors represents the colors used for the parts of a message and its fields when
+// the Highlight color format is set. A zero color leaves that kind of token uncolored.
+type TokenColors struct {
+	Key      Color // keys of key=value pairs and fields
+	Value    Color // values that are not one of the kinds below
+	Number   Color
+	String   Color // double quoted strings
+	Duration Color // durations such as 1.5s or 2h30m
+	Error    Color // error arguments and the values of err and error keys
+}
+
+// defaultTokens are the token colors of a new Logger.
+var defaultTokens = TokenColors{
+	Key:      CyanFg,
+	Number:   MagentaFg,
+	String:   GreenFg,
+	Duration: BlueFg,
+	Error:    RedFg | Bold,
+}
+
+// SetTokenColors sets the colors used by events with the Highlight color format.
+func (l *Logger) SetTokenColors(tc TokenColors) {
+	l.tokens = tc
+}
+
+// The kinds of tokens found in a message.
+const (
+	plainToken = iota
+	numberToken
+	durationToken
+)
+
+// A highlighter appends text with token colors.
+type highlighter struct {
+	tokens TokenColors
+	level  ColorLevel
+	base   Color    // colors restored after every token, zero for none
+	errs   []string // text of the error arguments
+}
+
+// highlighter returns the highlighter for an entry of the event. base is restored after
+// every token, so that tokens can be placed inside a colored message.
+func (e *Event) highlighter(en *entry, base Color) highlighter {
+	h := highlighter{e.Logger.tokens, e.Logger.colorLevel, base, nil}
+	for _, arg := range en.args {
+		if err, ok := arg.(error); ok && err != nil {
+			if s := errorText(err); s != "" {
+				h.errs = append(h.errs, s)
+			}
+		}
+	}
+	return h
+}
+
+// token appends b colored with c.
+func (h *highlighter) token(dst []byte, b []byte, c Color) []byte {
+	c = reduceColor(c, h.level)
+	if c == 0 {
+		return append(dst, b...)
+	}
+	dst = appendColorStart(dst, c)
+	dst = append(dst, b...)
+	dst = appendColorEnd(dst, c)
+	return appendColorStart(dst, h.base)
+}
+
+// appendMessage appends msg to dst with its keys, values, numbers, quoted strings,
+// durations and errors colored.
+func (h *highlighter) appendMessage(dst []byte, msg []byte) []byte {
+	for i := 0; i < len(msg); {
+		if n := h.errorLen(msg[i:]); n > 0 {
+			dst = h.token(dst, msg[i:i+n], h.tokens.Error)
+			i += n
+			continue
+		}
+		if msg[i] == '"' {
+			n := quotedLen(msg[i:])
+			dst = h.token(dst, msg[i:i+n], h.tokens.String)
+			i += n
+			continue
+		}
+		if i > 0 && isWordByte(msg[i-1]) {
+			dst = append(dst, msg[i])
+			i++
+			continue
+		}
+
+		n := wordLen(msg[i:])
+		if n == 0 {
+			dst = append(dst, msg[i])
+			i++
+			continue
+		}
+		word := msg[i : i+n]
+		i += n
+		if i < len(msg) && msg[i] == '=' && isKey(word) {
+			dst = h.token(dst, word, h.tokens.Key)
+			dst = append(dst, '=')
+			i++
+			v := valueLen(msg[i:])
+			dst = h.token(dst, msg[i:i+v], h.valueColor(word, msg[i:i+v]))
+			i += v
+			continue
+		}
+		switch kindOf(word) {
+		case numberToken:
+			dst = h.token(dst, word, h.tokens.Number)
+		case durationToken:
+			dst = h.token(dst, word, h.tokens.Duration)
+		default:
+			dst = append(dst, word...)
+		}
+	}
+	return dst
+}
+
+// appendFields appends fields like appendFields with their keys and values colored.
+func (h *highlighter) appendFields(dst []byte, fields []Field) []byte {
+	for _, f := range fields {
+		dst = append(dst, ' ')
+		dst = h.token(dst, []byte(f.Key), h.tokens.Key)
+		dst = append(dst, '=')
+
+		c := h.fieldColor(f)
+		c = reduceColor(c, h.level)
+		dst = appendColorStart(dst, c)
+		dst = appendValue(dst, f.Value)
+		dst = appendColorEnd(dst, c)
+	}
+	return dst
+}
+
+// valueColor returns the color of the value v of a key=value pair in a message.
+func (h *highlighter) valueColor(key, v []byte) Color {
+	switch {
+	case isErrorKey(string(key)):
+		return h.tokens.Error
+	case len(v) != 0 && v[0] == '"':
+		return h.tokens.String
+	}
+	switch kindOf(v) {
+	case numberToken:
+		return h.tokens.Number
+	case durationToken:
+		return h.tokens.Duration
+	}
+	return h.tokens.Value
+}
+
+// fieldColor returns the color of the value of a field.
+func (h *highlighter) fieldColor(f Field) Color {
+	if isErrorKey(f.Key) {
+		return h.tokens.Error
+	}
+	switch v := f.Value.(type) {
+	case error:
+		return h.tokens.Error
+	case time.Duration:
+		return h.tokens.Duration
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return h.tokens.Number
+	case string:
+		if needsQuote(v) {
+			return h.tokens.String
+		}
+	}
+	return h.tokens.Value
+}
+
+// errorLen returns the length of the error text at the start of b, or zero if there is
+// none.
+func (h *highlighter) errorLen(b []byte) int {
+	for _, s := range h.errs {
+		if len(b) >= len(s) && string(b[:len(s)]) == s {
+			return len(s)
+		}
+	}
+	return 0
+}
+
+// isErrorKey returns true if values of the key hold errors.
+func isErrorKey(key string) bool {
+	return key == "err" || key == "error"
+}
+
+// isWordByte returns true if c can be part of a word.
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '.' || c == '-' || c == '+' || c >= 0x80
+}
+
+// wordLen returns the length of the word at the start of b. A trailing period is not
+// part of the word, so that numbers at the end of a sentence are found.
+func wordLen(b []byte) int {
+	n := 0
+	for n < len(b) && isWordByte(b[n]) {
+		n++
+	}
+	for n > 0 && b[n-1] == '.' {
+		n--
+	}
+	return n
+}
+
+// valueLen returns the length of the value of a key=value pair at the start of b.
+func valueLen(b []byte) int {
+	if len(b) != 0 && b[0] == '"' {
+		return quotedLen(b)
+	}
+	n := bytes.IndexAny(b, " \t\n,;")
+	if n < 0 {
+		return len(b)
+	}
+	return n
+}
+
+// quotedLen returns the length of the double quoted string at the start of b, including
+// the quotes. An unterminated string runs to the end of b.
+func quotedLen(b []byte) int {
+	for i := 1; i < len(b); i++ {
+		switch b[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(b)
+}
+
+// isKey returns true if word can be the key of a key=value pair.
+func isKey(word []byte) bool {
+	c := word[0]
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+// kindOf returns whether word is a number, a duration or plain text.
+func kindOf(word []byte) int {
+	if len(word) != 0 && (word[0] == '-' || word[0] == '+') {
+		word = word[1:]
+	}
+	n := numberLen(word)
+	if n == 0 {
+		return plainToken
+	}
+	if n == len(word) {
+		return numberToken
+	}
+	for len(word) != 0 {
+		n := numberLen(word)
+		if n == 0 {
+			return plainToken
+		}
+		u := unitLen(word[n:])
+		if u == 0 {
+			return plainToken
+		}
+		word = word[n+u:]
+	}
+	return durationToken
+}
+
+// numberLen returns the length of the decimal number at the start of b, such as 12,
+// 1.5 or 6.02e23.
+func numberLen(b []byte) int {
+	n := digitsLen(b)
+	if n == 0 {
+		return 0
+	}
+	if n < len(b) && b[n] == '.' {
+		if d := digitsLen(b[n+1:]); d != 0 {
+			n += 1 + d
+		}
+	}
+	if n < len(b) && (b[n] == 'e' || b[n] == 'E') {
+		m := n + 1
+		if m < len(b) && (b[m] == '-' || b[m] == '+') {
+			m++
+		}
+		if d := digitsLen(b[m:]); d != 0 {
+			n = m + d
+		}
+	}
+	return n
+}
+
+// digitsLen returns the number of decimal digits at the start of b.
+func digitsLen(b []byte) int {
+	n := 0
+	for n < len(b) && b[n] >= '0' && b[n] <= '9' {
+		n++
+	}
+	return n
+}
+
+// unitLen returns the length of the duration unit at the start of b, as accepted by
+// time.ParseDuration.
+func unitLen(b []byte) int {
+	for _, u := range []string{"ns", "us", "µs", "ms", "h", "m", "s"} {
+		if len(b) >= len(u) && string(b[:len(u)]) == u {
+			return len(u)
+		}
+	}
+	return 0
+}
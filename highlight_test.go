@@ -0,0 +1,130 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestHighlighterAppendMessage(t *testing.T) {
+	h := highlighter{tokens: TokenColors{Key: CyanFg, Number: MagentaFg, String: GreenFg, Duration: BlueFg, Error: RedFg}, level: TrueColor}
+	h.errs = []string{"disk full"}
+
+	cases := []struct {
+		msg      string
+		expected string
+	}{
+		{"took 1.5s for 42 rows.", "took " + esc + "34m1.5s" + clear + " for " + esc + "35m42" + clear + " rows."},
+		{"user=bob age=7", esc + "36muser" + clear + "=bob " + esc + "36mage" + clear + "=" + esc + "35m7" + clear},
+		{`name="a b" err=oops`, esc + "36mname" + clear + "=" + esc + `32m"a b"` + clear + " " + esc + "36merr" + clear + "=" + esc + "31moops" + clear},
+		{"failed: disk full", "failed: " + esc + "31mdisk full" + clear},
+		{"v2 h264 1h30m -3", "v2 h264 " + esc + "34m1h30m" + clear + " " + esc + "35m-3" + clear},
+		{"5min a=", "5min " + esc + "36ma" + clear + "="},
+	}
+	for _, c := range cases {
+		actual := string(h.appendMessage(nil, []byte(c.msg)))
+		if actual != c.expected {
+			t.Errorf("Strings do not match for '%v', expected '%q' got '%q'", c.msg, c.expected, actual)
+		}
+	}
+
+	h.base = YellowFg
+	expected := "n " + esc + "35m1" + clear + esc + "33m"
+	if actual := string(h.appendMessage(nil, []byte("n 1"))); actual != expected {
+		t.Errorf("Message color was not restored, expected '%q' got '%q'", expected, actual)
+	}
+}
+
+func TestHighlighterAppendFields(t *testing.T) {
+	h := highlighter{tokens: TokenColors{Key: CyanFg, Value: GrayFg, Number: MagentaFg, String: GreenFg, Duration: BlueFg, Error: RedFg}, level: TrueColor}
+	fields := []Field{{"n", 3}, {"d", 2 * time.Second}, {"s", "a b"}, {"v", "x"}, {"e", errors.New("bad")}}
+	expected := " " + esc + "36mn" + clear + "=" + esc + "35m3" + clear +
+		" " + esc + "36md" + clear + "=" + esc + "34m2s" + clear +
+		" " + esc + "36ms" + clear + "=" + esc + `32m"a b"` + clear +
+		" " + esc + "36mv" + clear + "=" + esc + "37mx" + clear +
+		" " + esc + "36me" + clear + "=" + esc + "31mbad" + clear
+	if actual := string(h.appendFields(nil, fields)); actual != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, actual)
+	}
+}
+
+func TestEventHighlight(t *testing.T) {
+	test := New(false, true)
+	test.ShowCaller(false)
+	test.SetColorLevel(TrueColor)
+	if err := test.Error.SetColorFormat(Prefix | Highlight); err != nil {
+		t.Errorf("Error setting color format: %v", err)
+	}
+
+	actual, _ := test.Error.Log("wrote %v bytes: %v", 12, errors.New("short write"))
+	expected := esc + "31mERROR:" + clear + " wrote " + esc + "35m12" + clear + " bytes: " + esc + "1;31mshort write" + clear + "\n"
+	if actual != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, actual)
+	}
+
+	test.Error.ShowColor(false)
+	actual, _ = test.Error.Log("wrote %v bytes", 12)
+	if expected := "ERROR: wrote 12 bytes\n"; actual != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, actual)
+	}
+}
+
+// nilStringer is a Stringer whose String method panics on a nil pointer.
+type nilStringer struct {
+	s string
+}
+
+func (n *nilStringer) String() string { return n.s }
+
+func TestNilPointerValues(t *testing.T) {
+	var err *stackError
+	var str *nilStringer
+
+	test := New(false, true)
+	test.ShowCaller(false)
+	test.SetColorLevel(BasicColor)
+	test.Error.SetColorFormat(Highlight)
+	actual, _ := test.Error.LogFieldsFunc(fmt.Sprintf("Failed: %v", err), func() []Field {
+		return []Field{{"err", err}, {"name", str}}
+	})
+	if expected := "ERROR: Failed: <nil> " + esc + "36merr" + clear + "=" + esc + "1;31m<nil>" + clear + " " +
+		esc + "36mname" + clear + "=<nil>\n"; actual != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, actual)
+	}
+	actual, _ = test.Error.Log("Failed: %v", err)
+	if expected := "ERROR: Failed: " + esc + "1;31m<nil>" + clear + "\n"; actual != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, actual)
+	}
+
+	if actual := string(appendValue(nil, err)) + " " + string(appendValue(nil, str)); actual != "<nil> <nil>" {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", "<nil> <nil>", actual)
+	}
+	if actual := string(appendJSONValue(nil, err)) + " " + string(appendJSONValue(nil, str)); actual != `"\u003cnil\u003e" "\u003cnil\u003e"` {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", `"\u003cnil\u003e" "\u003cnil\u003e"`, actual)
+	}
+	if v := otlpValue(err); v.StringValue == nil || *v.StringValue != "<nil>" {
+		t.Errorf("Nil error was not exported as '<nil>', got '%+v'", v)
+	}
+	if v := otlpValue(str); v.StringValue == nil || *v.StringValue != "<nil>" {
+		t.Errorf("Nil Stringer was not exported as '<nil>', got '%+v'", v)
+	}
+}
+
+func TestLoggerSetThemeTokens(t *testing.T) {
+	test := New()
+	test.SetTheme("monochrome-bold")
+	if test.tokens.Key != Bold {
+		t.Errorf("Token colors of the theme were not applied")
+	}
+	RegisterTheme("plain-tokens", Theme{})
+	test.SetTheme("plain-tokens")
+	if test.tokens != defaultTokens {
+		t.Errorf("Default token colors were not used for a theme without token colors")
+	}
+}
@@ -0,0 +1,395 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// The BatchFormat type represents the body of the requests of an HTTPSink.
+type BatchFormat uint8
+
+// Request body formats of an HTTPSink.
+const (
+	JSONArray BatchFormat = iota // a JSON array of entries
+	NDJSON                       // one JSON entry per line
+)
+
+// Defaults of an HTTPConfig.
+const (
+	defaultBatchSize     = 100
+	defaultBatchInterval = time.Second
+	defaultHTTPTimeout   = 5 * time.Second
+	defaultRetries       = 3
+	defaultRetryWait     = 200 * time.Millisecond
+)
+
+// NoRetry is the Retries of an HTTPConfig that sends every batch only once.
+const NoRetry = -1
+
+// An HTTPConfig represents the settings of an HTTPSink. Zero values are replaced with the
+// defaults.
+type HTTPConfig struct {
+	URL       string
+	Format    BatchFormat       // JSONArray by default
+	Encoder   Encoder           // must encode entries as JSON, EncodeJSON by default
+	BatchSize int               // most entries sent in one request, 100 by default
+	Interval  time.Duration     // longest time an entry waits for its batch, 1s by default
+	Gzip      bool              // compress request bodies
+	Headers   map[string]string // added to every request, such as an Authorization header
+	Timeout   time.Duration     // longest time Write waits for room in the queue, the request timeout, and the longest time Flush and Close wait, 5s by default
+	QueueSize int               // entries waiting to be batched, 1024 by default
+	Retries   int               // retries of a batch that failed with a 5xx status or a network error, 3 by default, NoRetry for none
+	RetryWait time.Duration     // wait before the first retry, doubled for every retry, with jitter, 200ms by default
+	Client    *http.Client      // client used for requests, a client with the timeout by default
+}
+
+// An HTTPSink represents a batching client posting entries to an HTTP endpoint.
+type HTTPSink struct {
+	config   HTTPConfig
+	envelope [2][]byte // written before and after the JSON array of a batch
+	queue    chan []byte
+	flushes  chan chan error
+	mu       sync.RWMutex
+	closed   bool
+	quit     chan struct{}
+	done     chan struct{}
+	ctx      context.Context // canceled when Close gives up on the queued entries
+	cancel   context.CancelFunc
+	dropped  uint64
+	lost     uint64
+	errMu    sync.Mutex
+	err      error
+}
+
+// NewHTTPSink returns a sink that posts batches of entries to the URL of the config. A
+// batch is sent when it is full, when the interval of its first entry has passed, on
+// Flush and on Close.
+func NewHTTPSink(c HTTPConfig) (*HTTPSink, error) {
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("Invalid URL: " + c.URL)
+	}
+	if c.Format > NDJSON {
+		return nil, errors.New("Invalid batch format")
+	}
+	if c.BatchSize < 0 || c.Interval < 0 || c.Timeout < 0 || c.QueueSize < 0 || c.Retries < NoRetry || c.RetryWait < 0 {
+		return nil, errors.New("Invalid HTTP config")
+	}
+
+	if c.Encoder == nil {
+		c.Encoder = EncodeJSON
+	}
+	if c.BatchSize == 0 {
+		c.BatchSize = defaultBatchSize
+	}
+	if c.Interval == 0 {
+		c.Interval = defaultBatchInterval
+	}
+	if c.Timeout == 0 {
+		c.Timeout = defaultHTTPTimeout
+	}
+	if c.QueueSize == 0 {
+		c.QueueSize = defaultSpoolSize
+	}
+	switch c.Retries {
+	case 0:
+		c.Retries = defaultRetries
+	case NoRetry:
+		c.Retries = 0
+	}
+	if c.RetryWait == 0 {
+		c.RetryWait = defaultRetryWait
+	}
+	if c.Client == nil {
+		c.Client = &http.Client{Timeout: c.Timeout}
+	}
+
+	s := &HTTPSink{
+		config:  c,
+		queue:   make(chan []byte, c.QueueSize),
+		flushes: make(chan chan error),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	go s.run()
+	return s, nil
+}
+
+// Write encodes an entry and queues it for the next batch. If the queue is full, Write
+// waits up to the timeout of the config for room, then drops the entry and returns an
+// error.
+func (s *HTTPSink) Write(en *Entry) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return errors.New("HTTP sink is closed")
+	}
+
+	b := s.config.Encoder(nil, en)
+	select {
+	case s.queue <- b:
+		return nil
+	default:
+	}
+	timer := time.NewTimer(s.config.Timeout)
+	defer timer.Stop()
+	select {
+	case s.queue <- b:
+		return nil
+	case <-timer.C:
+		atomic.AddUint64(&s.dropped, 1)
+		return errors.New("HTTP queue is full, entry dropped")
+	}
+}
+
+// Flush sends the queued entries and returns the error of the last request, if any. It
+// gives up after the timeout of the config, the entries are still sent in the background.
+func (s *HTTPSink) Flush() error {
+	s.mu.RLock()
+	if s.closed {
+		s.mu.RUnlock()
+		return nil
+	}
+	timer := time.NewTimer(s.config.Timeout)
+	defer timer.Stop()
+	result := make(chan error, 1)
+	select {
+	case s.flushes <- result:
+	case <-timer.C:
+		s.mu.RUnlock()
+		return errors.New("Timed out flushing HTTP sink")
+	}
+	s.mu.RUnlock()
+	select {
+	case err := <-result:
+		return err
+	case <-timer.C:
+		return errors.New("Timed out flushing HTTP sink")
+	}
+}
+
+// Dropped returns the number of entries dropped because the queue was full.
+func (s *HTTPSink) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Err returns and clears the last error of a batch sent in the background.
+func (s *HTTPSink) Err() error {
+	s.errMu.Lock()
+	defer s.errMu.Unlock()
+	err := s.err
+	s.err = nil
+	return err
+}
+
+// Close sends the queued entries, without retries, and stops the background goroutine.
+// After the timeout of the config it cancels the requests and abandons the entries that
+// were not sent. It returns an error reporting the entries that were not sent, if any,
+// or the error of the last batch.
+func (s *HTTPSink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.mu.Unlock()
+	close(s.quit)
+
+	timer := time.NewTimer(s.config.Timeout)
+	defer timer.Stop()
+	select {
+	case <-s.done:
+	case <-timer.C:
+		s.cancel()
+		<-s.done
+	}
+	s.cancel()
+
+	err := s.Err()
+	if lost := atomic.LoadUint64(&s.lost); lost != 0 {
+		msg := strconv.FormatUint(lost, 10) + " queued entries were not sent"
+		if err != nil {
+			msg += ": " + err.Error()
+		}
+		return errors.New(msg)
+	}
+	return err
+}
+
+// run collects entries into batches and sends them until the sink is closed.
+func (s *HTTPSink) run() {
+	defer close(s.done)
+	var batch [][]byte
+	timer := time.NewTimer(s.config.Interval)
+	timer.Stop()
+
+	send := func() error {
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		err := s.post(batch)
+		if err != nil {
+			s.errMu.Lock()
+			s.err = err
+			s.errMu.Unlock()
+			select {
+			case <-s.quit:
+				atomic.AddUint64(&s.lost, uint64(len(batch)))
+			default:
+			}
+		}
+		batch = batch[:0]
+		return err
+	}
+	// drain moves the queued entries into batches, sending full ones.
+	drain := func() error {
+		var err error
+		for {
+			select {
+			case b := <-s.queue:
+				if batch = append(batch, b); len(batch) >= s.config.BatchSize {
+					err = send()
+				}
+			default:
+				if serr := send(); serr != nil {
+					err = serr
+				}
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case b := <-s.queue:
+			if len(batch) == 0 {
+				timer.Reset(s.config.Interval)
+			}
+			if batch = append(batch, b); len(batch) >= s.config.BatchSize {
+				send()
+			}
+		case <-timer.C:
+			send()
+		case result := <-s.flushes:
+			result <- drain()
+		case <-s.quit:
+			drain()
+			return
+		}
+	}
+}
+
+// post sends a batch, retrying on network errors and 5xx responses until the sink is
+// closed.
+func (s *HTTPSink) post(batch [][]byte) error {
+	body, err := s.body(batch)
+	if err != nil {
+		return err
+	}
+
+	wait := s.config.RetryWait
+	for attempt := 0; ; attempt++ {
+		retry, err := s.request(body)
+		if err == nil || !retry || attempt == s.config.Retries {
+			return err
+		}
+		// wait between half and one and a half times the backoff
+		timer := time.NewTimer(wait/2 + time.Duration(rand.Int63n(int64(wait)+1)))
+		select {
+		case <-timer.C:
+		case <-s.quit:
+			timer.Stop()
+			return err
+		}
+		wait *= 2
+	}
+}
+
+// request posts body once. It reports whether a failed request should be retried.
+func (s *HTTPSink) request(body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	if s.config.Format == NDJSON {
+		req.Header.Set("Content-Type", "application/x-ndjson")
+	} else {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.config.Gzip {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	for k, v := range s.config.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.config.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	return resp.StatusCode >= 500, errors.New("HTTP sink request failed: " + strconv.Itoa(resp.StatusCode) + " " + http.StatusText(resp.StatusCode))
+}
+
+// body returns the request body for a batch, compressed if the config asks for it.
+func (s *HTTPSink) body(batch [][]byte) ([]byte, error) {
+	var buf bytes.Buffer
+	var w io.Writer = &buf
+	var zw *gzip.Writer
+	if s.config.Gzip {
+		zw = gzip.NewWriter(&buf)
+		w = zw
+	}
+
+	if s.config.Format == NDJSON {
+		for _, b := range batch {
+			w.Write(b)
+			w.Write([]byte{'\n'})
+		}
+	} else {
+		w.Write(s.envelope[0])
+		w.Write([]byte{'['})
+		for i, b := range batch {
+			if i != 0 {
+				w.Write([]byte{','})
+			}
+			w.Write(b)
+		}
+		w.Write([]byte{']'})
+		w.Write(s.envelope[1])
+	}
+
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,262 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// batchServer records the bodies of the requests it receives.
+type batchServer struct {
+	mu      sync.Mutex
+	bodies  []string
+	headers []http.Header
+	status  int32 // status returned for the next failures
+	fails   int32 // number of requests left to fail
+}
+
+func (b *batchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if atomic.AddInt32(&b.fails, -1) >= 0 {
+		w.WriteHeader(int(atomic.LoadInt32(&b.status)))
+		return
+	}
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body = zr
+	}
+	data, _ := io.ReadAll(body)
+	b.mu.Lock()
+	b.bodies = append(b.bodies, string(data))
+	b.headers = append(b.headers, r.Header)
+	b.mu.Unlock()
+}
+
+func (b *batchServer) received() ([]string, []http.Header) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.bodies...), append([]http.Header(nil), b.headers...)
+}
+
+func TestNewHTTPSink(t *testing.T) {
+	invalid := []HTTPConfig{
+		{URL: "localhost:8080"},
+		{URL: "ftp://example.com"},
+		{URL: "http://example.com", Format: NDJSON + 1},
+		{URL: "http://example.com", BatchSize: -1},
+	}
+	for _, c := range invalid {
+		if _, err := NewHTTPSink(c); err == nil {
+			t.Errorf("Invalid config '%+v' did not trigger error", c)
+		}
+	}
+}
+
+func TestHTTPSinkBatchSize(t *testing.T) {
+	server := &batchServer{}
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	sink, err := NewHTTPSink(HTTPConfig{
+		URL:       srv.URL,
+		BatchSize: 2,
+		Interval:  time.Hour,
+		Headers:   map[string]string{"Authorization": "Bearer token"},
+	})
+	if err != nil {
+		t.Fatalf("Error creating HTTP sink: %v", err)
+	}
+	test := New(false, false)
+	defer discardStderr(t)()
+	test.AddSink(sink)
+	test.SetLogLevel(All)
+
+	test.Error.Log("one")
+	test.Info.Log("two")
+	test.Debug.Log("three")
+	if err := sink.Flush(); err != nil {
+		t.Errorf("Error flushing HTTP sink: %v", err)
+	}
+
+	bodies, headers := server.received()
+	if len(bodies) != 2 {
+		t.Fatalf("Wrong number of requests, expected '%v' got '%v'", 2, len(bodies))
+	}
+	var batch []map[string]interface{}
+	if err := json.Unmarshal([]byte(bodies[0]), &batch); err != nil {
+		t.Fatalf("Body is not a JSON array: %v", err)
+	}
+	if len(batch) != 2 || batch[0]["msg"] != "one" || batch[1]["level"] != "info" {
+		t.Errorf("Batch does not match the logged entries, got '%v'", bodies[0])
+	}
+	if !strings.Contains(bodies[1], `"msg":"three"`) {
+		t.Errorf("Flushed batch does not contain the last entry, got '%v'", bodies[1])
+	}
+	if headers[0].Get("Authorization") != "Bearer token" || headers[0].Get("Content-Type") != "application/json" {
+		t.Errorf("Headers were not sent, got '%v'", headers[0])
+	}
+	test.Close(time.Second)
+}
+
+func TestHTTPSinkInterval(t *testing.T) {
+	server := &batchServer{}
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	sink, _ := NewHTTPSink(HTTPConfig{URL: srv.URL, Format: NDJSON, Gzip: true, Interval: 20 * time.Millisecond})
+	defer sink.Close()
+	sink.Write(&Entry{Message: "a"})
+	sink.Write(&Entry{Message: "b"})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if bodies, _ := server.received(); len(bodies) != 0 {
+			lines := strings.Split(strings.TrimSuffix(bodies[0], "\n"), "\n")
+			if len(lines) != 2 || !strings.Contains(lines[1], `"msg":"b"`) {
+				t.Errorf("Body is not NDJSON of the entries, got '%v'", bodies[0])
+			}
+			_, headers := server.received()
+			if headers[0].Get("Content-Type") != "application/x-ndjson" {
+				t.Errorf("Wrong content type, got '%v'", headers[0].Get("Content-Type"))
+			}
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Errorf("Batch was not sent after the interval")
+}
+
+func TestHTTPSinkRetry(t *testing.T) {
+	server := &batchServer{status: http.StatusServiceUnavailable, fails: 2}
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	sink, _ := NewHTTPSink(HTTPConfig{URL: srv.URL, RetryWait: time.Millisecond})
+	sink.Write(&Entry{Message: "retried"})
+	if err := sink.Flush(); err != nil {
+		t.Errorf("Batch was not retried: %v", err)
+	}
+	if bodies, _ := server.received(); len(bodies) != 1 {
+		t.Errorf("Wrong number of delivered batches, expected '%v' got '%v'", 1, len(bodies))
+	}
+
+	atomic.StoreInt32(&server.status, http.StatusBadRequest)
+	atomic.StoreInt32(&server.fails, 5)
+	sink.Write(&Entry{Message: "rejected"})
+	if err := sink.Flush(); err == nil || !strings.Contains(err.Error(), "400") {
+		t.Errorf("Rejected batch did not trigger error, got '%v'", err)
+	}
+	if fails := atomic.LoadInt32(&server.fails); fails != 4 {
+		t.Errorf("Batch rejected with 4xx was retried")
+	}
+	sink.Close()
+
+	atomic.StoreInt32(&server.status, http.StatusServiceUnavailable)
+	atomic.StoreInt32(&server.fails, 5)
+	sink, _ = NewHTTPSink(HTTPConfig{URL: srv.URL, Retries: NoRetry, RetryWait: time.Millisecond})
+	sink.Write(&Entry{Message: "once"})
+	if err := sink.Flush(); err == nil {
+		t.Errorf("Failed batch without retries did not trigger error")
+	}
+	if fails := atomic.LoadInt32(&server.fails); fails != 4 {
+		t.Errorf("Batch was retried with NoRetry, expected '%v' attempts got '%v'", 1, 5-fails)
+	}
+	sink.Close()
+	if _, err := NewHTTPSink(HTTPConfig{URL: srv.URL, Retries: NoRetry - 1}); err == nil {
+		t.Errorf("Invalid retries did not trigger error")
+	}
+}
+
+func TestHTTPSinkClose(t *testing.T) {
+	server := &batchServer{}
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	sink, _ := NewHTTPSink(HTTPConfig{URL: srv.URL, Interval: time.Hour})
+	test := New(false, false)
+	defer discardStderr(t)()
+	test.AddSink(sink)
+	test.Error.Log("last words")
+	if err := test.Close(time.Second); err != nil {
+		t.Errorf("Error closing logger: %v", err)
+	}
+	if bodies, _ := server.received(); len(bodies) != 1 || !strings.Contains(bodies[0], "last words") {
+		t.Errorf("Queued entries were not sent on close, got '%v'", bodies)
+	}
+	if err := sink.Write(&Entry{}); err == nil {
+		t.Errorf("Write after close did not trigger error")
+	}
+}
+
+func TestHTTPSinkCloseTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	sink, _ := NewHTTPSink(HTTPConfig{
+		URL:       srv.URL,
+		BatchSize: 1,
+		Interval:  time.Hour,
+		Timeout:   100 * time.Millisecond,
+		Client:    &http.Client{},
+	})
+	for i := 0; i < 3; i++ {
+		sink.Write(&Entry{Message: "stuck"})
+	}
+	if err := sink.Flush(); err == nil {
+		t.Errorf("Flush against a hanging endpoint did not time out")
+	}
+
+	start := time.Now()
+	err := sink.Close()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Close was not bounded by the timeout, took '%v'", elapsed)
+	}
+	if err == nil || !strings.Contains(err.Error(), "3 queued entries were not sent") {
+		t.Errorf("Abandoned entries were not reported, got '%v'", err)
+	}
+}
+
+func TestHTTPSinkCloseDuringBackoff(t *testing.T) {
+	server := &batchServer{status: http.StatusServiceUnavailable, fails: 100}
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	sink, _ := NewHTTPSink(HTTPConfig{URL: srv.URL, BatchSize: 1, RetryWait: time.Hour, Timeout: time.Second})
+	sink.Write(&Entry{Message: "retried"})
+	// wait for the first attempt, the sink is then waiting to retry
+	for atomic.LoadInt32(&server.fails) == 100 {
+		time.Sleep(time.Millisecond)
+	}
+
+	start := time.Now()
+	err := sink.Close()
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Close waited for the retry backoff, took '%v'", elapsed)
+	}
+	if err == nil || !strings.Contains(err.Error(), "1 queued entries were not sent") {
+		t.Errorf("Abandoned entries were not reported, got '%v'", err)
+	}
+}
@@ -0,0 +1,25 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+//go:build linux
+
+package logger
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// isTerminal returns true if f is a terminal. It asks the terminal driver for the
+// settings of f, which only succeeds for terminals.
+func isTerminal(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+	var termios syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TCGETS, uintptr(unsafe.Pointer(&termios)))
+	return errno == 0
+}
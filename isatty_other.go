@@ -0,0 +1,25 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+//go:build !linux
+
+package logger
+
+import (
+	"os"
+)
+
+// isTerminal returns true if f is a character device, which is the best guess available
+// without platform specific calls.
+func isTerminal(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,146 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// journalSocket is the socket of the native protocol of systemd-journald.
+const journalSocket = "/run/systemd/journal/socket"
+
+// maxJournalName is the longest journal field name accepted by systemd-journald.
+const maxJournalName = 64
+
+// A JournalSink represents a connection to systemd-journald.
+type JournalSink struct {
+	mu         sync.Mutex
+	identifier string
+	path       string
+	conn       *net.UnixConn
+	fallback   io.Writer
+}
+
+// NewJournalSink returns a sink that writes to the journal using its native protocol.
+// Entries are sent with PRIORITY, MESSAGE and SYSLOG_IDENTIFIER, CODE_FILE, CODE_LINE and
+// CODE_FUNC when callers are shown, and their fields as uppercase journal fields. When
+// the journal socket does not exist, entries are written to the stderr instead with a
+// priority prefix such as "<5>".
+func NewJournalSink(identifier string) *JournalSink {
+	return &JournalSink{
+		identifier: identifier,
+		path:       journalSocket,
+		fallback:   os.Stderr,
+	}
+}
+
+// Write sends an entry to the journal.
+func (s *JournalSink) Write(en *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: s.path, Net: "unixgram"})
+		if err != nil {
+			return s.writeFallback(en)
+		}
+		s.conn = conn
+	}
+	if _, err := s.conn.Write(s.message(en)); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+// Close closes the connection to the journal.
+func (s *JournalSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// writeFallback writes an entry to the fallback writer, prefixed with its priority.
+func (s *JournalSink) writeFallback(en *Entry) error {
+	dst := []byte("<" + strconv.Itoa(syslogSeverity(en.Level)) + ">")
+	dst = append(dst, en.Message...)
+	dst = appendFields(dst, en.Fields)
+	dst = append(dst, '\n')
+	_, err := s.fallback.Write(dst)
+	return err
+}
+
+// message returns the native protocol datagram for an entry.
+func (s *JournalSink) message(en *Entry) []byte {
+	var dst []byte
+	dst = appendJournalField(dst, "PRIORITY", strconv.Itoa(syslogSeverity(en.Level)))
+	dst = appendJournalField(dst, "MESSAGE", en.Message)
+	if s.identifier != "" {
+		dst = appendJournalField(dst, "SYSLOG_IDENTIFIER", s.identifier)
+	}
+	if en.File != "" {
+		dst = appendJournalField(dst, "CODE_FILE", en.File)
+		dst = appendJournalField(dst, "CODE_LINE", strconv.Itoa(en.Line))
+		if en.Function != "" {
+			dst = appendJournalField(dst, "CODE_FUNC", en.Function)
+		}
+	}
+	for _, f := range en.Fields {
+		dst = appendJournalField(dst, journalName(f.Key), fmt.Sprint(f.Value))
+	}
+	if en.Stack != "" {
+		dst = appendJournalField(dst, "STACK_TRACE", en.Stack)
+	}
+	return dst
+}
+
+// appendJournalField appends a field in the native protocol. Values containing newlines
+// are sent with their length in front.
+func appendJournalField(dst []byte, name, value string) []byte {
+	dst = append(dst, name...)
+	if !strings.Contains(value, "\n") {
+		dst = append(dst, '=')
+		dst = append(dst, value...)
+		return append(dst, '\n')
+	}
+	dst = append(dst, '\n')
+	dst = binary.LittleEndian.AppendUint64(dst, uint64(len(value)))
+	dst = append(dst, value...)
+	return append(dst, '\n')
+}
+
+// journalName returns key as a valid journal field name. Names are uppercase letters,
+// digits and underscores, may not start with an underscore or a digit, and are at most
+// 64 characters.
+func journalName(key string) string {
+	b := []byte(strings.ToUpper(key))
+	for i, c := range b {
+		if !(c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			b[i] = '_'
+		}
+	}
+	name := strings.TrimLeft(string(b), "_")
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		name = "F_" + name
+	}
+	if len(name) > maxJournalName {
+		name = name[:maxJournalName]
+	}
+	return name
+}
@@ -0,0 +1,83 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"bytes"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJournalSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "socket")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("Unix sockets not available: %v", err)
+	}
+	defer conn.Close()
+
+	sink := NewJournalSink("myapp")
+	sink.path = path
+	defer sink.Close()
+	err = sink.Write(&Entry{
+		Time:     time.Now(),
+		Level:    Normal,
+		Message:  "Disk low",
+		Fields:   []Field{{"free.bytes", 12}, {"note", "a\nb"}},
+		File:     "/src/main.go",
+		Line:     42,
+		Function: "main.main",
+	})
+	if err != nil {
+		t.Fatalf("Error writing to journal: %v", err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	buf := make([]byte, 4096)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("Error reading journal message: %v", err)
+	}
+	expected := "PRIORITY=5\nMESSAGE=Disk low\nSYSLOG_IDENTIFIER=myapp\nCODE_FILE=/src/main.go\n" +
+		"CODE_LINE=42\nCODE_FUNC=main.main\nFREE_BYTES=12\nNOTE\n\x03\x00\x00\x00\x00\x00\x00\x00a\nb\n"
+	if actual := string(buf[:n]); actual != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, actual)
+	}
+}
+
+func TestJournalSinkFallback(t *testing.T) {
+	var out bytes.Buffer
+	sink := NewJournalSink("myapp")
+	sink.path = filepath.Join(t.TempDir(), "missing")
+	sink.fallback = &out
+
+	test := New(false, false)
+	defer discardStderr(t)()
+	test.AddSink(sink)
+	test.Error.LogFieldsFunc("Failed", func() []Field { return []Field{{"id", 7}} })
+	if expected := "<3>Failed id=7\n"; out.String() != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, out.String())
+	}
+}
+
+func TestJournalName(t *testing.T) {
+	cases := map[string]string{
+		"component":             "COMPONENT",
+		"error.chain":           "ERROR_CHAIN",
+		"_hidden":               "HIDDEN",
+		"9lives":                "F_9LIVES",
+		"":                      "F_",
+		strings.Repeat("k", 70): strings.Repeat("K", 64),
+	}
+	for key, expected := range cases {
+		if actual := journalName(key); actual != expected {
+			t.Errorf("Names do not match, expected '%v' got '%v'", expected, actual)
+		}
+	}
+}
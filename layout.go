@@ -0,0 +1,150 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+)
+
+// Padding represents how the prefix column is padded to the width of the longest prefix.
+type Padding int
+
+// Padding modes for the prefix column.
+const (
+	NoPadding Padding = iota // prefixes are not padded
+	PadRight                 // prefixes are left aligned
+	PadLeft                  // prefixes are right aligned
+)
+
+// SetLevelPadding sets how prefixes are padded so that the messages of every log event
+// start in the same column.
+func (l *Logger) SetLevelPadding(p Padding) error {
+	if p < NoPadding || p > PadLeft {
+		return errors.New("Invalid padding")
+	}
+	l.padding = p
+	return nil
+}
+
+// referenceTimes are rendered to find the width of a timestamp layout, which is the
+// widest of them. Their month, day and hour have two digits in every layout, and the
+// second falls on a Wednesday in September, the longest day and month names.
+func referenceTimes(loc *time.Location) [2]time.Time {
+	return [2]time.Time{
+		time.Date(2006, 12, 28, 22, 59, 59, 999999999, loc),
+		time.Date(2006, 9, 27, 22, 59, 59, 999999999, loc),
+	}
+}
+
+// referenceElapsed is rendered to find the width of elapsed times. The timestamp column
+// keeps its width for the first day a logger runs, and grows after that.
+const referenceElapsed = 99999*time.Second + 999*time.Millisecond
+
+// A columnWidth represents the width of the timestamp column of an output style, cached
+// since it only changes with the timestamp settings.
+type columnWidth struct {
+	style OutputStyle
+	loc   *time.Location
+	width int
+}
+
+// timestampWidth returns the width of the timestamp column of an output, the width of the
+// widest timestamp shown by any log event in its style.
+func (l *Logger) timestampWidth(loc *time.Location, st OutputStyle) int {
+	if !l.timestamp {
+		return 0
+	}
+	// colors do not change the width
+	st = OutputStyle{Format: st.Format, Layout: st.Layout}
+	slot := &l.widths[0]
+	if st.Format != l.stderrStyle.Format || st.Layout != l.stderrStyle.Layout {
+		slot = &l.widths[1]
+	}
+	if c := slot.Load(); c != nil && c.style == st && c.loc == loc {
+		return c.width
+	}
+
+	var buf [64]byte
+	refs := referenceTimes(loc)
+	width := 0
+	for _, e := range l.events() {
+		format, layout := e.timeSettings(st)
+		if !e.timestamp || (layout == "" && !validateTimestamp(format)) {
+			continue
+		}
+		for _, ref := range refs {
+			if n := visibleWidth(e.appendTime(buf[:0], ref, referenceElapsed, referenceElapsed, format, layout)); n > width {
+				width = n
+			}
+		}
+	}
+	slot.Store(&columnWidth{st, loc, width})
+	return width
+}
+
+// resetWidths drops the cached widths of the timestamp column after its settings changed.
+func (l *Logger) resetWidths() {
+	for i := range l.widths {
+		l.widths[i].Store(nil)
+	}
+}
+
+// prefixWidth returns the width of the longest prefix of the log events.
+func (l *Logger) prefixWidth() int {
+	width := 0
+	for _, e := range l.events() {
+		if n := utf8.RuneCountInString(e.Prefix()); n > width {
+			width = n
+		}
+	}
+	return width
+}
+
+// appendPrefix appends the prefix of the event padded to the prefix column.
+func (e *Event) appendPrefix(dst []byte, colored bool) []byte {
+	pad := 0
+	if e.Logger.padding != NoPadding {
+		pad = e.Logger.prefixWidth() - utf8.RuneCountInString(e.Prefix())
+	}
+	if e.Logger.padding == PadLeft {
+		dst = appendPadding(dst, pad)
+	}
+	dst = appendColored(dst, e.Prefix(), e.shownColors(), colored)
+	if e.Logger.padding == PadRight {
+		dst = appendPadding(dst, pad)
+	}
+	return dst
+}
+
+// appendPadding appends n spaces to dst.
+func appendPadding(dst []byte, n int) []byte {
+	for ; n > 0; n-- {
+		dst = append(dst, ' ')
+	}
+	return dst
+}
+
+// visibleWidth returns the number of characters of b shown on a terminal. ANSI escape
+// sequences are not counted.
+func visibleWidth(b []byte) int {
+	n := 0
+	for i := 0; i < len(b); {
+		if b[i] == '\033' && i+1 < len(b) && b[i+1] == '[' {
+			i += 2
+			for i < len(b) && (b[i] < 0x40 || b[i] > 0x7e) {
+				i++
+			}
+			i++
+			continue
+		}
+		_, size := utf8.DecodeRune(b[i:])
+		i += size
+		n++
+	}
+	return n
+}
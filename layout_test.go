@@ -0,0 +1,119 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVisibleWidth(t *testing.T) {
+	cases := []struct {
+		s        string
+		expected int
+	}{
+		{"", 0},
+		{"ERROR:", 6},
+		{esc + "31mERROR:" + clear, 6},
+		{esc + "38;2;1;2;3mé" + clear + " x", 3},
+	}
+	for _, c := range cases {
+		if actual := visibleWidth([]byte(c.s)); actual != c.expected {
+			t.Errorf("Width of '%q' does not match, expected '%v' got '%v'", c.s, c.expected, actual)
+		}
+	}
+}
+
+func TestEventTimestampColumn(t *testing.T) {
+	test := New(true, true)
+	test.ShowCaller(false)
+	test.SetLogLevel(All)
+	now := time.Date(2018, 2, 5, 4, 3, 4, 0, time.UTC)
+	test.SetClock(ClockFunc(func() time.Time { return now }))
+	test.Debug.SetColorFormat(Timestamp)
+	test.Info.SetFormat(Time24Hour)
+
+	debug, _ := test.Debug.Log("a")
+	info, _ := test.Info.Log("b")
+	// the widest timestamp is that of 12/28/2006 10:59:59 PM UTC
+	width := len("12/28/2006 10:59:59 PM UTC")
+	for _, res := range []string{debug, info} {
+		i := strings.Index(res, " - ")
+		if actual := visibleWidth([]byte(res[:i])); actual != width {
+			t.Errorf("Timestamps not aligned, expected column '%v' got '%v' in '%q'", width, actual, res)
+		}
+	}
+	expected := "04:03:04" + strings.Repeat(" ", width-8) + " - "
+	if !strings.HasPrefix(info, expected) {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, info)
+	}
+}
+
+func TestElapsedTimestampColumn(t *testing.T) {
+	test := New(true, false)
+	test.ShowCaller(false)
+	test.SetClock(stepClock(time.Date(2018, 2, 5, 14, 3, 4, 0, time.UTC), 9*time.Second))
+	test.SetLayout(Elapsed)
+
+	first, _ := test.Error.Log("a")
+	second, _ := test.Error.Log("b")
+	if i, j := strings.Index(first, " - "), strings.Index(second, " - "); i != j {
+		t.Errorf("Timestamps not aligned once elapsed time reached 10s, got '%q' and '%q'", first, second)
+	}
+	if expected := len("+99999.999s"); test.timestampWidth(time.UTC, test.stderrStyle) != expected {
+		t.Errorf("Width of elapsed times does not match, expected '%v' got '%v'", expected, test.timestampWidth(time.UTC, test.stderrStyle))
+	}
+}
+
+func TestTimestampWidthCache(t *testing.T) {
+	test := New(true, false)
+	if actual := test.timestampWidth(time.UTC, test.stderrStyle); actual != len("12/28/2006 10:59:59 PM UTC") {
+		t.Errorf("Width does not match, expected '%v' got '%v'", len("12/28/2006 10:59:59 PM UTC"), actual)
+	}
+	test.SetLayout(time.Kitchen)
+	if actual := test.timestampWidth(time.UTC, test.stderrStyle); actual != len("10:59PM") {
+		t.Errorf("Cached width was not reset, expected '%v' got '%v'", len("10:59PM"), actual)
+	}
+	if actual := test.timestampWidth(time.UTC, OutputStyle{Format: Time24Hour}); actual != len("22:59:59") {
+		t.Errorf("Width of another style does not match, expected '%v' got '%v'", len("22:59:59"), actual)
+	}
+	test.SetLayout("Monday, January 2")
+	if actual := test.timestampWidth(time.UTC, test.stderrStyle); actual != len("Wednesday, September 27") {
+		t.Errorf("Width of day and month names does not match, expected '%v' got '%v'", len("Wednesday, September 27"), actual)
+	}
+	test.SetLayout(time.Kitchen)
+	test.ShowElapsed(true)
+	if actual := test.timestampWidth(time.UTC, test.stderrStyle); actual != len("10:59PM +99999.999s") {
+		t.Errorf("Cached width was not reset, expected '%v' got '%v'", len("10:59PM +99999.999s"), actual)
+	}
+}
+
+func TestLoggerSetLevelPadding(t *testing.T) {
+	test := New(false, false)
+	test.ShowCaller(false)
+	if err := test.SetLevelPadding(PadLeft + 1); err == nil {
+		t.Errorf("Invalid padding did not trigger error")
+	}
+
+	test.SetLevelPadding(PadRight)
+	res, _ := test.Error.Log("message")
+	if expected := "ERROR:  message\n"; res != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, res)
+	}
+
+	test.SetLevelPadding(PadLeft)
+	res, _ = test.Error.Log("message")
+	if expected := " ERROR: message\n"; res != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, res)
+	}
+
+	test.SetLevelPadding(NoPadding)
+	res, _ = test.Error.Log("message")
+	if expected := "ERROR: message\n"; res != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, res)
+	}
+}
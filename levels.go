@@ -0,0 +1,101 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import "errors"
+
+// An outputLevels represents the minimum levels of single outputs. Outputs without their
+// own level use the log level of the logger.
+type outputLevels struct {
+	names map[string]LogLevel
+	masks [ErrorsOnly + 1]uint64 // outputs accepting each level
+	min   LogLevel               // lowest level accepted by an output
+}
+
+// SetOutputLevel sets the minimum level of a single output, such as Normal for the
+// stderr and All for the save log. name is StderrOutput, FileOutput or the name of a
+// sink added with AddNamedSink. Test turns the output off. Outputs without their own
+// level use the level set with SetLogLevel. An event is enabled when any output accepts
+// it.
+func (l *Logger) SetOutputLevel(name string, lv LogLevel) error {
+	if lv > Test {
+		return errors.New("Invalid log level")
+	}
+	if name != StderrOutput && name != FileOutput && l.sinkIndex(name) < 0 {
+		return errors.New("Unknown output: " + name)
+	}
+	if l.levels == nil {
+		l.levels = &outputLevels{names: map[string]LogLevel{}}
+	}
+	l.levels.names[name] = lv
+	l.updateLevels()
+	return nil
+}
+
+// OutputLevel returns the minimum level of an output.
+func (l *Logger) OutputLevel(name string) LogLevel {
+	if l.levels != nil {
+		if lv, ok := l.levels.names[name]; ok {
+			return lv
+		}
+	}
+	return l.logLevel
+}
+
+// ResetOutputLevels makes every output use the log level of the logger again.
+func (l *Logger) ResetOutputLevels() {
+	l.levels = nil
+}
+
+// minLevel returns the lowest level accepted by an output of the logger.
+func (l *Logger) minLevel() LogLevel {
+	if l.levels == nil {
+		return l.logLevel
+	}
+	return l.levels.min
+}
+
+// levelOutputs returns the outputs accepting entries of the given level.
+func (l *Logger) levelOutputs(level LogLevel) uint64 {
+	if l.levels == nil {
+		return allOutputs
+	}
+	return l.levels.masks[level]
+}
+
+// updateLevels computes the outputs accepting each level, after the levels or the sinks
+// of the logger changed.
+func (l *Logger) updateLevels() {
+	o := l.levels
+	if o == nil {
+		return
+	}
+	o.masks = [ErrorsOnly + 1]uint64{}
+	o.min = Test
+	// enables says whether the output exists, only existing outputs enable events
+	accept := func(bits uint64, min LogLevel, enables bool) {
+		for lv := min; lv <= ErrorsOnly; lv++ {
+			o.masks[lv] |= bits
+		}
+		if enables && min < o.min {
+			o.min = min
+		}
+	}
+	accept(stderrBit, l.OutputLevel(StderrOutput), true)
+	accept(fileBit, l.OutputLevel(FileOutput), l.toDisk)
+	for i, name := range l.sinkNames {
+		switch {
+		case i >= maxNamedSinks:
+			// sinks past the output set receive every entry at the log level
+			accept(0, l.logLevel, true)
+		case name == "":
+			// unnamed sinks use the log level of the logger
+			accept(firstSinkBit<<uint(i), l.logLevel, true)
+		default:
+			accept(firstSinkBit<<uint(i), l.OutputLevel(name), true)
+		}
+	}
+}
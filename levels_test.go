@@ -0,0 +1,148 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoggerSetOutputLevel(t *testing.T) {
+	dir := t.TempDir()
+	test := New(false, false)
+	if err := test.SaveLog(dir); err != nil {
+		t.Fatalf("Error saving log: %v", err)
+	}
+	network := &memorySink{}
+	all := &memorySink{}
+	test.AddNamedSink("network", network)
+	test.AddSink(all)
+
+	if err := test.SetOutputLevel(FileOutput, All); err != nil {
+		t.Errorf("Error setting output level: %v", err)
+	}
+	if err := test.SetOutputLevel("network", ErrorsOnly); err != nil {
+		t.Errorf("Error setting output level: %v", err)
+	}
+	if !test.Debug.Enabled() {
+		t.Errorf("Debug is not enabled while the file accepts it")
+	}
+	if lv := test.OutputLevel(StderrOutput); lv != Normal {
+		t.Errorf("Output levels do not match, expected '%v' got '%v'", Normal, lv)
+	}
+
+	stderr := captureStderr(t)
+	test.Debug.Log("details")
+	test.Notice.Log("started")
+	test.Error.Log("failed")
+	output := stderr()
+
+	if expected := "NOTICE: started\nERROR: failed\n"; output != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, output)
+	}
+	b, _ := os.ReadFile(filepath.Join(dir, "log.log"))
+	if expected := "DEBUG: details\nNOTICE: started\nERROR: failed\n"; string(b) != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, string(b))
+	}
+	if len(network.entries) != 1 || network.entries[0].Message != "failed" {
+		t.Errorf("Wrong entries written to the network sink, got '%v'", network.messages())
+	}
+	if len(all.entries) != 2 {
+		t.Errorf("Unnamed sink did not use the log level, got '%v'", all.messages())
+	}
+
+	test.SetLogLevel(ErrorsOnly)
+	if !test.Debug.Enabled() || test.OutputLevel(StderrOutput) != ErrorsOnly {
+		t.Errorf("Log level changed the level of the file")
+	}
+	test.ResetOutputLevels()
+	if test.Debug.Enabled() || test.OutputLevel(FileOutput) != ErrorsOnly {
+		t.Errorf("Output levels were not reset")
+	}
+}
+
+func TestOutputLevelsAboveLogLevel(t *testing.T) {
+	test := New(false, false)
+	if err := test.SaveLog(t.TempDir()); err != nil {
+		t.Fatalf("Error saving log: %v", err)
+	}
+	test.SetLogLevel(All)
+	test.SetOutputLevel(StderrOutput, ErrorsOnly)
+	test.SetOutputLevel(FileOutput, ErrorsOnly)
+	if test.Debug.Enabled() || !test.Error.Enabled() {
+		t.Errorf("Events not enabled by the output levels, expected '%v' got '%v'", ErrorsOnly, test.minLevel())
+	}
+	called := false
+	test.Debug.LogFunc(func() string { called = true; return "" })
+	if called {
+		t.Errorf("Message built for an entry no output accepts")
+	}
+
+	sink := &memorySink{}
+	test.AddSink(sink)
+	if !test.Debug.Enabled() {
+		t.Errorf("Unnamed sink at the log level did not enable the event")
+	}
+
+	test = New(false, false)
+	test.SetLogLevel(All)
+	test.SetOutputLevel(StderrOutput, Normal)
+	test.SetOutputLevel(FileOutput, All)
+	if test.Debug.Enabled() {
+		t.Errorf("Level of the file enabled events without a save log")
+	}
+}
+
+func TestLoggerSetOutputLevelInvalid(t *testing.T) {
+	test := New(false, false)
+	if err := test.SetOutputLevel("missing", All); err == nil {
+		t.Errorf("Unknown output did not trigger error")
+	}
+	if err := test.SetOutputLevel(StderrOutput, Test+1); err == nil {
+		t.Errorf("Invalid level did not trigger error")
+	}
+	if test.levels != nil {
+		t.Errorf("Invalid output level was set")
+	}
+}
+
+func TestLoggerSetOutputLevelTest(t *testing.T) {
+	defer discardStderr(t)()
+	test := New(false, false)
+	test.ShowCaller(false)
+	sink := &memorySink{}
+	test.AddNamedSink("memory", sink)
+	if err := test.SetOutputLevel(StderrOutput, Test); err != nil {
+		t.Errorf("Error setting output level: %v", err)
+	}
+	if set := test.outputs(ErrorsOnly, nil); set&stderrBit != 0 {
+		t.Errorf("Output turned off with Test accepts entries, got '%b'", set)
+	}
+
+	res, err := test.Error.Log("Test message")
+	if err != nil {
+		t.Errorf("Error logging event: %v", err)
+	}
+	if res != "" {
+		t.Errorf("Entry not shown on the stderr was returned, got '%q'", res)
+	}
+	if len(sink.messages()) != 1 {
+		t.Errorf("Entry was not passed to the sink, got '%v'", sink.messages())
+	}
+}
+
+func TestOutputLevelsWithRoutes(t *testing.T) {
+	test := New(false, false)
+	test.SetOutputLevel(FileOutput, All)
+	test.SetRoutes(Route{To: []string{StderrOutput, FileOutput}})
+	if set := test.outputs(All, nil); set != fileBit {
+		t.Errorf("Output sets do not match, expected '%b' got '%b'", fileBit, set)
+	}
+	if set := test.outputs(ErrorsOnly, nil); set != stderrBit|fileBit {
+		t.Errorf("Output sets do not match, expected '%b' got '%b'", stderrBit|fileBit, set)
+	}
+}
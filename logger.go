@@ -6,7 +6,10 @@
 package logger
 
 import (
+	"errors"
 	"os"
+	"sync/atomic"
+	"time"
 
 	"github.com/logrusorgru/aurora"
 )
@@ -17,6 +20,12 @@ type LogLevel uint8
 // The ColorFormat type represents formatting flags for the colorizer.
 type ColorFormat uint8
 
+// A Field represents a key/value pair attached to a log entry.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
 // Constants for defining LogLevels.
 const (
 	All        LogLevel = iota // All events will be logged.
@@ -39,18 +48,51 @@ const (
 	timemask   = TimeZone
 )
 
+// Preset timestamp layouts for use with SetLayout. Any other Go time layout may be used
+// as well. UnixSeconds and UnixMillis are not Go layouts, they show the time since the
+// Unix epoch. Elapsed shows the time since the logger was created and SincePrevious
+// shows the time since the previous entry of the same event, such as "+1.234s".
+const (
+	RFC3339       = time.RFC3339
+	RFC3339Nano   = time.RFC3339Nano
+	ISO8601Milli  = "2006-01-02T15:04:05.000Z07:00"
+	UnixSeconds   = "unix"
+	UnixMillis    = "unixmilli"
+	Elapsed       = "elapsed"
+	SincePrevious = "sinceprevious"
+)
+
 // A Logger represents a collection of event loggers.
 type Logger struct {
-	logLevel  LogLevel
-	timestamp bool
-	colored   bool
-	au        aurora.Aurora
-	toDisk    bool
-	logPath   string
-	Debug     Event // Debug event controller
-	Info      Event // Info event controller
-	Notice    Event // Notice event controller
-	Error     Event // Error event controller
+	logLevel    LogLevel
+	timestamp   bool
+	colored     bool
+	au          aurora.Aurora
+	toDisk      bool
+	logPath     string
+	caller      bool
+	callerSkip  int
+	async       *asyncQueue
+	location    *time.Location
+	clock       Clock
+	start       time.Time
+	elapsed     bool
+	colorAuto   bool
+	ttyColor    bool
+	colorLevel  ColorLevel
+	tokens      TokenColors
+	padding     Padding
+	sinks       []Sink
+	sinkNames   []string
+	routes      *router
+	levels      *outputLevels
+	stderrStyle OutputStyle
+	fileStyle   OutputStyle
+	widths      [2]atomic.Pointer[columnWidth]
+	Debug       Event // Debug event controller
+	Info        Event // Info event controller
+	Notice      Event // Notice event controller
+	Error       Event // Error event controller
 }
 
 // Color format flags for determining which parts of an event log get colored.
@@ -59,18 +101,20 @@ const (
 	Timestamp ColorFormat = 1 << iota
 	Prefix
 	Message
-	cformatMask = Timestamp | Prefix | Message
+	Caller
+	Highlight
+	cformatMask = Timestamp | Prefix | Message | Caller | Highlight
 )
 
 // Wrappers for aurora special formats.
 const (
-	Bold aurora.Color = 1 << iota
+	Bold Color = 1 << iota
 	Inverse
 )
 
 // Wrappers for aurora foreground colors.
 const (
-	BlackFg aurora.Color = (1 + iota) << 8
+	BlackFg Color = (1 + iota) << 8
 	RedFg
 	GreenFg
 	YellowFg
@@ -92,10 +136,11 @@ const (
 	GrayBg
 )
 
-// New creates a new Logger based on the arguments. An empty New() will return a Logger with default settings. Optional arguments are called with following format New(colored, showtimestamp). This is effectively the same as making a new Logger and then calling logger.ShowColor(true) and logger.ShowTimestamp(true).
+// New creates a new Logger based on the arguments. An empty New() will return a Logger with default settings. Optional arguments are called with following format New(colored, showtimestamp). This is effectively the same as making a new Logger and then calling logger.ShowColor(true) and logger.ShowTimestamp(true). When colored is not given, colors are only used if the stderr supports them.
 func New(a ...bool) *Logger {
 	c := true
 	ts := true
+	auto := len(a) < 2
 	if len(a) != 0 {
 		if !a[0] {
 			ts = false
@@ -115,10 +160,29 @@ func New(a ...bool) *Logger {
 		aurora.NewAurora(c),
 		false,
 		"",
-		Event{&l, true, true, GreenFg, ShortDate | Time12Hour | TimeZone, Prefix, "DEBUG:"},
-		Event{&l, true, true, GrayFg, ShortDate | Time12Hour | TimeZone, Prefix, "INFO:"},
-		Event{&l, true, true, YellowFg, ShortDate | Time12Hour | TimeZone, Prefix, "NOTICE:"},
-		Event{&l, true, true, RedFg, ShortDate | Time12Hour | TimeZone, Prefix, "ERROR:"},
+		false,
+		0,
+		nil,
+		nil,
+		wallClock{},
+		time.Now(),
+		false,
+		auto,
+		colorSupport(os.Stderr),
+		detectColorLevel(),
+		defaultTokens,
+		NoPadding,
+		nil,
+		nil,
+		nil,
+		nil,
+		OutputStyle{},
+		OutputStyle{Color: NeverColor},
+		[2]atomic.Pointer[columnWidth]{},
+		Event{&l, true, true, GreenFg, ShortDate | Time12Hour | TimeZone, Prefix, "DEBUG:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
+		Event{&l, true, true, GrayFg, ShortDate | Time12Hour | TimeZone, Prefix, "INFO:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
+		Event{&l, true, true, YellowFg, ShortDate | Time12Hour | TimeZone, Prefix, "NOTICE:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
+		Event{&l, true, true, RedFg, ShortDate | Time12Hour | TimeZone, Prefix, "ERROR:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
 	}
 
 	return &l
@@ -129,19 +193,51 @@ func (l *Logger) LogLevel() LogLevel {
 	return l.logLevel
 }
 
-// SetLogLevel sets the logLevel to the given LogLevel.
+// SetLogLevel sets the logLevel to the given LogLevel. It is the minimum level of every
+// output without its own level, see SetOutputLevel.
 func (l *Logger) SetLogLevel(lv LogLevel) {
 	l.logLevel = lv
+	l.updateLevels()
 }
 
 // ShowTimestamp sets whether or not to show timestamps for the entire logger.
 func (l *Logger) ShowTimestamp(b bool) {
 	l.timestamp = b
+	l.resetWidths()
 }
 
-// ShowColor sets whether or not to use colors for the entire logger.
+// ShowColor sets whether or not to use colors for the entire logger. This overrides the
+// automatic detection of whether the stderr supports colors.
 func (l *Logger) ShowColor(b bool) {
 	l.colored = b
+	l.colorAuto = false
+}
+
+// SetLayout sets the timestamp layout of every log event. See Event.SetLayout.
+func (l *Logger) SetLayout(layout string) error {
+	if ok := validateLayout(layout); !ok {
+		return errors.New("Invalid timestamp layout")
+	}
+	for _, e := range l.events() {
+		e.layout = layout
+	}
+	l.resetWidths()
+	return nil
+}
+
+// SetLocation sets the time zone timestamps are shown in, such as time.UTC, time.Local or
+// a location returned by time.LoadLocation. A nil location shows local time.
+func (l *Logger) SetLocation(loc *time.Location) {
+	l.location = loc
+	l.resetWidths()
+}
+
+// locationOf returns the location the timestamp for t is shown in.
+func (l *Logger) locationOf(t time.Time) *time.Location {
+	if l.location != nil {
+		return l.location
+	}
+	return t.Location()
 }
 
 // SaveLog will save the log to a file on disk at the given path.
@@ -149,6 +245,7 @@ func (l *Logger) SaveLog(path string) error {
 	logFile := "/log.log"
 	if !l.toDisk {
 		l.toDisk = true
+		l.updateLevels()
 	}
 
 	_, err := os.Stat(path)
@@ -171,6 +268,23 @@ func (l *Logger) SaveLog(path string) error {
 // StopSaveLog will stop logging from happening with the current logger.
 func (l *Logger) StopSaveLog() {
 	l.toDisk = false
+	l.updateLevels()
+}
+
+// events returns the log events of the logger.
+func (l *Logger) events() []*Event {
+	return []*Event{&l.Debug, &l.Info, &l.Notice, &l.Error}
+}
+
+// validateLayout returns true if the given timestamp layout shows at least part of the
+// time. An empty layout is valid and selects the format flags.
+func validateLayout(layout string) bool {
+	switch layout {
+	case "", UnixSeconds, UnixMillis, Elapsed, SincePrevious:
+		return true
+	}
+	t := time.Date(2001, 2, 3, 4, 5, 6, 7, time.UTC)
+	return t.Format(layout) != layout
 }
 
 // validateTimestamp returns true if the given timestamp format is valid.
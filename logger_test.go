@@ -11,6 +11,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -31,13 +32,33 @@ func TestNew(t *testing.T) {
 		aurora.NewAurora(true),
 		false,
 		"",
-		Event{&defexpected, true, true, GreenFg, ShortDate | Time12Hour | TimeZone, Prefix, "DEBUG:"},
-		Event{&defexpected, true, true, GrayFg, ShortDate | Time12Hour | TimeZone, Prefix, "INFO:"},
-		Event{&defexpected, true, true, YellowFg, ShortDate | Time12Hour | TimeZone, Prefix, "NOTICE:"},
-		Event{&defexpected, true, true, RedFg, ShortDate | Time12Hour | TimeZone, Prefix, "ERROR:"},
+		false,
+		0,
+		nil,
+		nil,
+		wallClock{},
+		time.Time{},
+		false,
+		true,
+		colorSupport(os.Stderr),
+		detectColorLevel(),
+		defaultTokens,
+		NoPadding,
+		nil,
+		nil,
+		nil,
+		nil,
+		OutputStyle{},
+		OutputStyle{Color: NeverColor},
+		[2]atomic.Pointer[columnWidth]{},
+		Event{&defexpected, true, true, GreenFg, ShortDate | Time12Hour | TimeZone, Prefix, "DEBUG:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
+		Event{&defexpected, true, true, GrayFg, ShortDate | Time12Hour | TimeZone, Prefix, "INFO:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
+		Event{&defexpected, true, true, YellowFg, ShortDate | Time12Hour | TimeZone, Prefix, "NOTICE:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
+		Event{&defexpected, true, true, RedFg, ShortDate | Time12Hour | TimeZone, Prefix, "ERROR:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
 	}
 
 	defactual := New()
+	defexpected.start = defactual.start
 	if !reflect.DeepEqual(defactual, &defexpected) {
 		t.Errorf("Default logger not created correctly")
 	}
@@ -50,13 +71,33 @@ func TestNew(t *testing.T) {
 		aurora.NewAurora(true),
 		false,
 		"",
-		Event{&ntsexpected, true, true, GreenFg, ShortDate | Time12Hour | TimeZone, Prefix, "DEBUG:"},
-		Event{&ntsexpected, true, true, GrayFg, ShortDate | Time12Hour | TimeZone, Prefix, "INFO:"},
-		Event{&ntsexpected, true, true, YellowFg, ShortDate | Time12Hour | TimeZone, Prefix, "NOTICE:"},
-		Event{&ntsexpected, true, true, RedFg, ShortDate | Time12Hour | TimeZone, Prefix, "ERROR:"},
+		false,
+		0,
+		nil,
+		nil,
+		wallClock{},
+		time.Time{},
+		false,
+		true,
+		colorSupport(os.Stderr),
+		detectColorLevel(),
+		defaultTokens,
+		NoPadding,
+		nil,
+		nil,
+		nil,
+		nil,
+		OutputStyle{},
+		OutputStyle{Color: NeverColor},
+		[2]atomic.Pointer[columnWidth]{},
+		Event{&ntsexpected, true, true, GreenFg, ShortDate | Time12Hour | TimeZone, Prefix, "DEBUG:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
+		Event{&ntsexpected, true, true, GrayFg, ShortDate | Time12Hour | TimeZone, Prefix, "INFO:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
+		Event{&ntsexpected, true, true, YellowFg, ShortDate | Time12Hour | TimeZone, Prefix, "NOTICE:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
+		Event{&ntsexpected, true, true, RedFg, ShortDate | Time12Hour | TimeZone, Prefix, "ERROR:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
 	}
 
 	ntsactual := New(false)
+	ntsexpected.start = ntsactual.start
 
 	if !reflect.DeepEqual(ntsactual, &ntsexpected) {
 		t.Errorf("No timestamp logger not created correctly")
@@ -70,13 +111,33 @@ func TestNew(t *testing.T) {
 		aurora.NewAurora(false),
 		false,
 		"",
-		Event{&ncexpected, true, true, GreenFg, ShortDate | Time12Hour | TimeZone, Prefix, "DEBUG:"},
-		Event{&ncexpected, true, true, GrayFg, ShortDate | Time12Hour | TimeZone, Prefix, "INFO:"},
-		Event{&ncexpected, true, true, YellowFg, ShortDate | Time12Hour | TimeZone, Prefix, "NOTICE:"},
-		Event{&ncexpected, true, true, RedFg, ShortDate | Time12Hour | TimeZone, Prefix, "ERROR:"},
+		false,
+		0,
+		nil,
+		nil,
+		wallClock{},
+		time.Time{},
+		false,
+		false,
+		colorSupport(os.Stderr),
+		detectColorLevel(),
+		defaultTokens,
+		NoPadding,
+		nil,
+		nil,
+		nil,
+		nil,
+		OutputStyle{},
+		OutputStyle{Color: NeverColor},
+		[2]atomic.Pointer[columnWidth]{},
+		Event{&ncexpected, true, true, GreenFg, ShortDate | Time12Hour | TimeZone, Prefix, "DEBUG:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
+		Event{&ncexpected, true, true, GrayFg, ShortDate | Time12Hour | TimeZone, Prefix, "INFO:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
+		Event{&ncexpected, true, true, YellowFg, ShortDate | Time12Hour | TimeZone, Prefix, "NOTICE:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
+		Event{&ncexpected, true, true, RedFg, ShortDate | Time12Hour | TimeZone, Prefix, "ERROR:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
 	}
 
 	ncactual := New(true, false)
+	ncexpected.start = ncactual.start
 
 	if !reflect.DeepEqual(ncactual, &ncexpected) {
 		t.Errorf("No color logger not created correctly")
@@ -90,13 +151,33 @@ func TestNew(t *testing.T) {
 		aurora.NewAurora(false),
 		false,
 		"",
-		Event{&falseexpected, true, true, GreenFg, ShortDate | Time12Hour | TimeZone, Prefix, "DEBUG:"},
-		Event{&falseexpected, true, true, GrayFg, ShortDate | Time12Hour | TimeZone, Prefix, "INFO:"},
-		Event{&falseexpected, true, true, YellowFg, ShortDate | Time12Hour | TimeZone, Prefix, "NOTICE:"},
-		Event{&falseexpected, true, true, RedFg, ShortDate | Time12Hour | TimeZone, Prefix, "ERROR:"},
+		false,
+		0,
+		nil,
+		nil,
+		wallClock{},
+		time.Time{},
+		false,
+		false,
+		colorSupport(os.Stderr),
+		detectColorLevel(),
+		defaultTokens,
+		NoPadding,
+		nil,
+		nil,
+		nil,
+		nil,
+		OutputStyle{},
+		OutputStyle{Color: NeverColor},
+		[2]atomic.Pointer[columnWidth]{},
+		Event{&falseexpected, true, true, GreenFg, ShortDate | Time12Hour | TimeZone, Prefix, "DEBUG:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
+		Event{&falseexpected, true, true, GrayFg, ShortDate | Time12Hour | TimeZone, Prefix, "INFO:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
+		Event{&falseexpected, true, true, YellowFg, ShortDate | Time12Hour | TimeZone, Prefix, "NOTICE:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
+		Event{&falseexpected, true, true, RedFg, ShortDate | Time12Hour | TimeZone, Prefix, "ERROR:", true, ShortFile, false, false, "", nil, atomic.Int64{}},
 	}
 
 	falseactual := New(false, false)
+	falseexpected.start = falseactual.start
 	if !reflect.DeepEqual(falseactual, &falseexpected) {
 		t.Errorf("No options logger not created correctly")
 	}
@@ -166,6 +247,8 @@ func TestLoggerShowColor(t *testing.T) {
 func TestLoggerSaveLog(t *testing.T) {
 	message := "Test message"
 	test := New()
+	now := time.Date(2018, 2, 5, 14, 3, 4, 0, time.Local)
+	test.SetClock(ClockFunc(func() time.Time { return now }))
 	if err := test.SaveLog("log"); err != nil {
 		t.Errorf("Error creating save log: %v", err)
 	}
@@ -188,7 +271,7 @@ func TestLoggerSaveLog(t *testing.T) {
 	line := string(b)
 	line = trimSpaces(line)
 	fmt.Println("Line:", line)
-	tn := time.Now()
+	tn := now
 	tf := tn.Format("1/2/2006 3:04:05 PM MST")
 	expected := tf + " - " + test.Error.Prefix() + " " + message
 	expected = trimSpaces(expected)
@@ -213,3 +296,37 @@ func TestLoggerStopSaveLog(t *testing.T) {
 func trimSpaces(s string) string {
 	return strings.Join(strings.Fields(s), " ")
 }
+
+func TestLoggerSetLayout(t *testing.T) {
+	test := New()
+	if err := test.SetLayout(RFC3339); err != nil {
+		t.Errorf("Error setting layout: %v", err)
+	}
+	for _, e := range test.events() {
+		if e.layout != RFC3339 {
+			t.Errorf("Layout was not set for %v, expected '%v' got '%v'", e.Prefix(), RFC3339, e.layout)
+		}
+	}
+	if err := test.SetLayout("nothing"); err == nil {
+		t.Errorf("Invalid layout did not trigger error")
+	}
+}
+
+func TestLoggerSetLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("Time zone database not available: %v", err)
+	}
+	test := New()
+	test.SetLocation(loc)
+	test.Error.SetLayout("15:04 MST")
+	now := time.Date(2018, 2, 5, 14, 3, 0, 0, time.UTC)
+	b, err := test.Error.appendTimestamp(nil, &entry{time: now}, OutputStyle{})
+	if err != nil {
+		t.Errorf("Error building timestamp: %v", err)
+	}
+	expected := "09:03 EST"
+	if actual := trimSpaces(string(b)); actual != expected {
+		t.Errorf("Date doesn't match, expected '%v' got '%v'", expected, actual)
+	}
+}
@@ -0,0 +1,289 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// The Framing type represents how entries are separated on a TCP connection.
+type Framing uint8
+
+// Framing modes of a NetworkSink.
+const (
+	NewlineFraming Framing = iota // every entry is followed by a newline
+	LengthFraming                 // every entry is preceded by its length as a 4 byte big endian integer
+)
+
+// Defaults of a NetworkConfig.
+const (
+	defaultNetworkTimeout = time.Second
+	defaultSpoolSize      = 1024
+	defaultMinBackoff     = 100 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// A NetworkConfig represents the settings of a NetworkSink. Zero values are replaced
+// with the defaults.
+type NetworkConfig struct {
+	Network    string        // "tcp" or "udp"
+	Address    string        // host:port
+	Framing    Framing       // framing on TCP connections, NewlineFraming by default
+	Encoder    Encoder       // EncodeJSON by default
+	TLS        *tls.Config   // enables TLS on TCP connections
+	Timeout    time.Duration // longest time Write waits for room in the spool, and the dial and write timeout, 1s by default
+	SpoolSize  int           // number of entries kept while disconnected, 1024 by default
+	MinBackoff time.Duration // first wait before reconnecting, 100ms by default
+	MaxBackoff time.Duration // longest wait before reconnecting, 30s by default
+}
+
+// A NetworkSink represents a stream of encoded entries sent to a network address.
+// Entries are queued in an in-memory spool and sent by a background goroutine, which
+// reconnects with exponential backoff when the connection is lost.
+type NetworkSink struct {
+	config  NetworkConfig
+	spool   chan []byte
+	mu      sync.RWMutex
+	closed  bool
+	quit    chan struct{}
+	done    chan struct{}
+	conn    net.Conn
+	dropped uint64
+	lost    int
+	errMu   sync.Mutex
+	err     error
+}
+
+// NewNetworkSink returns a sink that sends entries to the address of the config. It does
+// not wait for the connection, entries are spooled until it is established.
+func NewNetworkSink(c NetworkConfig) (*NetworkSink, error) {
+	switch c.Network {
+	case "tcp", "tcp4", "tcp6":
+	case "udp", "udp4", "udp6":
+		if c.TLS != nil {
+			return nil, errors.New("TLS is not supported over UDP")
+		}
+	default:
+		return nil, errors.New("Invalid network: " + c.Network)
+	}
+	if _, _, err := net.SplitHostPort(c.Address); err != nil {
+		return nil, errors.New("Invalid address: " + c.Address)
+	}
+	if c.Framing > LengthFraming {
+		return nil, errors.New("Invalid framing")
+	}
+	if c.Timeout < 0 || c.SpoolSize < 0 || c.MinBackoff < 0 || c.MaxBackoff < 0 {
+		return nil, errors.New("Invalid network config")
+	}
+
+	if c.Encoder == nil {
+		c.Encoder = EncodeJSON
+	}
+	if c.Timeout == 0 {
+		c.Timeout = defaultNetworkTimeout
+	}
+	if c.SpoolSize == 0 {
+		c.SpoolSize = defaultSpoolSize
+	}
+	if c.MinBackoff == 0 {
+		c.MinBackoff = defaultMinBackoff
+	}
+	if c.MaxBackoff == 0 {
+		c.MaxBackoff = defaultMaxBackoff
+	}
+	if c.MaxBackoff < c.MinBackoff {
+		c.MaxBackoff = c.MinBackoff
+	}
+
+	s := &NetworkSink{
+		config: c,
+		spool:  make(chan []byte, c.SpoolSize),
+		quit:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go s.run()
+	return s, nil
+}
+
+// Write encodes an entry and adds it to the spool. If the spool is full, Write waits up
+// to the timeout of the config for room, then drops the entry and returns an error.
+func (s *NetworkSink) Write(en *Entry) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return errors.New("Network sink is closed")
+	}
+
+	b := s.config.Encoder(nil, en)
+	select {
+	case s.spool <- b:
+		return nil
+	default:
+	}
+	timer := time.NewTimer(s.config.Timeout)
+	defer timer.Stop()
+	select {
+	case s.spool <- b:
+		return nil
+	case <-timer.C:
+		atomic.AddUint64(&s.dropped, 1)
+		return errors.New("Network spool is full, entry dropped")
+	}
+}
+
+// Dropped returns the number of entries dropped because the spool was full.
+func (s *NetworkSink) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Err returns and clears the last error of the background goroutine, such as a failed
+// connection attempt.
+func (s *NetworkSink) Err() error {
+	s.errMu.Lock()
+	defer s.errMu.Unlock()
+	err := s.err
+	s.err = nil
+	return err
+}
+
+// Close sends the spooled entries and closes the connection. It gives up after the
+// timeout of the config and returns an error if entries could not be sent.
+func (s *NetworkSink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.mu.Unlock()
+	close(s.quit)
+
+	select {
+	case <-s.done:
+	case <-time.After(s.config.Timeout):
+		return errors.New("Timed out closing network sink")
+	}
+	if s.lost != 0 {
+		return errors.New(strconv.Itoa(s.lost) + " spooled entries were not sent")
+	}
+	return nil
+}
+
+// run sends spooled entries until the sink is closed. An entry that could not be sent is
+// retried after a backoff that doubles with every failure.
+func (s *NetworkSink) run() {
+	defer close(s.done)
+	backoff := s.config.MinBackoff
+	var pending []byte
+	for {
+		if pending == nil {
+			select {
+			case pending = <-s.spool:
+			case <-s.quit:
+				s.drain(nil)
+				return
+			}
+		}
+		if err := s.send(pending); err != nil {
+			s.setErr(err)
+			select {
+			case <-time.After(backoff):
+			case <-s.quit:
+				s.drain(pending)
+				return
+			}
+			if backoff *= 2; backoff > s.config.MaxBackoff {
+				backoff = s.config.MaxBackoff
+			}
+			continue
+		}
+		pending = nil
+		backoff = s.config.MinBackoff
+	}
+}
+
+// drain makes a last attempt to send pending and the spooled entries, then closes the
+// connection. Entries left when sending fails are counted as lost.
+func (s *NetworkSink) drain(pending []byte) {
+	defer func() {
+		if s.conn != nil {
+			s.conn.Close()
+			s.conn = nil
+		}
+	}()
+	if pending != nil {
+		if err := s.send(pending); err != nil {
+			s.lost = 1 + len(s.spool)
+			return
+		}
+	}
+	for {
+		select {
+		case b := <-s.spool:
+			if err := s.send(b); err != nil {
+				s.lost = 1 + len(s.spool)
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// send writes a single framed entry, connecting first if needed. The connection is
+// dropped when the write fails.
+func (s *NetworkSink) send(b []byte) error {
+	if s.conn == nil {
+		conn, err := s.dial()
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+	s.conn.SetWriteDeadline(time.Now().Add(s.config.Timeout))
+	if _, err := s.conn.Write(s.frame(b)); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+// dial opens the connection to the address of the config.
+func (s *NetworkSink) dial() (net.Conn, error) {
+	d := &net.Dialer{Timeout: s.config.Timeout}
+	if s.config.TLS != nil {
+		return tls.DialWithDialer(d, s.config.Network, s.config.Address, s.config.TLS)
+	}
+	return d.Dial(s.config.Network, s.config.Address)
+}
+
+// frame returns b framed for the network of the config. UDP datagrams are not framed.
+func (s *NetworkSink) frame(b []byte) []byte {
+	switch s.config.Network {
+	case "udp", "udp4", "udp6":
+		return b
+	}
+	if s.config.Framing == LengthFraming {
+		dst := binary.BigEndian.AppendUint32(make([]byte, 0, 4+len(b)), uint32(len(b)))
+		return append(dst, b...)
+	}
+	return append(b, '\n')
+}
+
+// setErr records an error of the background goroutine.
+func (s *NetworkSink) setErr(err error) {
+	s.errMu.Lock()
+	s.err = err
+	s.errMu.Unlock()
+}
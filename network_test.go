@@ -0,0 +1,248 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// acceptLines accepts connections on ln and sends every newline framed entry to lines.
+func acceptLines(ln net.Listener, lines chan<- string) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			scanner := bufio.NewScanner(conn)
+			for scanner.Scan() {
+				lines <- scanner.Text()
+			}
+		}()
+	}
+}
+
+// receive returns the next line, or fails the test after a timeout.
+func receive(t *testing.T, lines <-chan string) string {
+	t.Helper()
+	select {
+	case line := <-lines:
+		return line
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Timed out waiting for entry")
+	}
+	return ""
+}
+
+func TestNewNetworkSink(t *testing.T) {
+	invalid := []NetworkConfig{
+		{Network: "http", Address: "localhost:80"},
+		{Network: "tcp", Address: "localhost"},
+		{Network: "udp", Address: "localhost:80", TLS: &tls.Config{}},
+		{Network: "tcp", Address: "localhost:80", Framing: LengthFraming + 1},
+		{Network: "tcp", Address: "localhost:80", Timeout: -1},
+	}
+	for _, c := range invalid {
+		if _, err := NewNetworkSink(c); err == nil {
+			t.Errorf("Invalid config '%+v' did not trigger error", c)
+		}
+	}
+}
+
+func TestNetworkSinkTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("TCP not available: %v", err)
+	}
+	defer ln.Close()
+	lines := make(chan string, 8)
+	go acceptLines(ln, lines)
+
+	sink, err := NewNetworkSink(NetworkConfig{Network: "tcp", Address: ln.Addr().String(), Encoder: EncodeText})
+	if err != nil {
+		t.Fatalf("Error creating network sink: %v", err)
+	}
+	test := New(false, false)
+	defer discardStderr(t)()
+	test.AddSink(sink)
+	now := time.Date(2018, 2, 5, 14, 3, 4, 0, time.UTC)
+	test.SetClock(ClockFunc(func() time.Time { return now }))
+
+	test.Error.Log("Failed")
+	test.Notice.LogFieldsFunc("Done", func() []Field { return []Field{{"n", 2}} })
+	if actual, expected := receive(t, lines), "2018-02-05T14:03:04.000Z ERROR: Failed"; actual != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, actual)
+	}
+	if actual, expected := receive(t, lines), "2018-02-05T14:03:04.000Z NOTICE: Done n=2"; actual != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, actual)
+	}
+	if err := test.Close(time.Second); err != nil {
+		t.Errorf("Error closing logger: %v", err)
+	}
+}
+
+func TestNetworkSinkLengthFraming(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("TCP not available: %v", err)
+	}
+	defer ln.Close()
+	frames := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var size [4]byte
+		if _, err := io.ReadFull(conn, size[:]); err != nil {
+			return
+		}
+		b := make([]byte, binary.BigEndian.Uint32(size[:]))
+		io.ReadFull(conn, b)
+		frames <- string(b)
+	}()
+
+	sink, _ := NewNetworkSink(NetworkConfig{Network: "tcp", Address: ln.Addr().String(), Framing: LengthFraming})
+	defer sink.Close()
+	sink.Write(&Entry{Time: time.Date(2018, 2, 5, 14, 3, 4, 0, time.UTC), Level: Verbose, Message: "Hi"})
+	expected := `{"time":"2018-02-05T14:03:04Z","level":"info","msg":"Hi"}`
+	if actual := receive(t, frames); actual != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, actual)
+	}
+}
+
+func TestNetworkSinkUDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("UDP not available: %v", err)
+	}
+	defer pc.Close()
+
+	sink, _ := NewNetworkSink(NetworkConfig{Network: "udp", Address: pc.LocalAddr().String(), Encoder: EncodeText})
+	defer sink.Close()
+	sink.Write(&Entry{Time: time.Date(2018, 2, 5, 14, 3, 4, 0, time.UTC), Prefix: "INFO:", Message: "Hi"})
+
+	pc.SetReadDeadline(time.Now().Add(2 * time.Second))
+	buf := make([]byte, 1024)
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("Error reading datagram: %v", err)
+	}
+	if actual, expected := string(buf[:n]), "2018-02-05T14:03:04.000Z INFO: Hi"; actual != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, actual)
+	}
+}
+
+func TestNetworkSinkReconnect(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("TCP not available: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	sink, _ := NewNetworkSink(NetworkConfig{
+		Network:    "tcp",
+		Address:    addr,
+		Encoder:    EncodeText,
+		MinBackoff: 10 * time.Millisecond,
+		MaxBackoff: 40 * time.Millisecond,
+	})
+	defer sink.Close()
+	for _, msg := range []string{"one", "two", "three"} {
+		if err := sink.Write(&Entry{Prefix: "INFO:", Message: msg}); err != nil {
+			t.Errorf("Error spooling entry: %v", err)
+		}
+	}
+	time.Sleep(50 * time.Millisecond)
+	if sink.Err() == nil {
+		t.Errorf("Failed connection was not reported")
+	}
+
+	ln, err = net.Listen("tcp", addr)
+	if err != nil {
+		t.Skipf("Address was taken: %v", err)
+	}
+	defer ln.Close()
+	lines := make(chan string, 8)
+	go acceptLines(ln, lines)
+	for _, msg := range []string{"one", "two", "three"} {
+		if actual := receive(t, lines); !strings.HasSuffix(actual, "INFO: "+msg) {
+			t.Errorf("Spooled entry not sent in order, expected '%v' got '%v'", msg, actual)
+		}
+	}
+}
+
+func TestNetworkSinkTimeout(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("TCP not available: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	sink, _ := NewNetworkSink(NetworkConfig{
+		Network:    "tcp",
+		Address:    addr,
+		Timeout:    50 * time.Millisecond,
+		SpoolSize:  1,
+		MinBackoff: time.Second,
+	})
+	test := New(false, false)
+	defer discardStderr(t)()
+	test.AddSink(sink)
+
+	start := time.Now()
+	var failed bool
+	for i := 0; i < 4; i++ {
+		if _, err := test.Error.Log("Entry %v", i); err != nil {
+			failed = true
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Log was blocked by the network sink for %v", elapsed)
+	}
+	if !failed || sink.Dropped() == 0 {
+		t.Errorf("Full spool did not drop entries")
+	}
+	if err := sink.Close(); err == nil {
+		t.Errorf("Unsent entries were not reported")
+	}
+	if err := sink.Write(&Entry{}); err == nil {
+		t.Errorf("Write after close did not trigger error")
+	}
+}
+
+func TestNetworkSinkTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", srv.TLS)
+	if err != nil {
+		t.Skipf("TLS not available: %v", err)
+	}
+	defer ln.Close()
+	lines := make(chan string, 1)
+	go acceptLines(ln, lines)
+
+	client := srv.Client().Transport.(*http.Transport).TLSClientConfig
+	sink, _ := NewNetworkSink(NetworkConfig{Network: "tcp", Address: ln.Addr().String(), Encoder: EncodeText, TLS: client})
+	defer sink.Close()
+	sink.Write(&Entry{Prefix: "ERROR:", Message: "secret"})
+	if actual := receive(t, lines); !strings.HasSuffix(actual, "ERROR: secret") {
+		t.Errorf("Entry not received over TLS, got '%v'", actual)
+	}
+}
@@ -0,0 +1,204 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Keys of the fields holding the trace context of an entry. They are sent as the trace
+// and span IDs of OTLP log records.
+const (
+	TraceIDKey = "trace_id"
+	SpanIDKey  = "span_id"
+)
+
+// defaultOTLPEndpoint is the logs endpoint of a collector running on this machine.
+const defaultOTLPEndpoint = "http://localhost:4318/v1/logs"
+
+// otlpScope is the instrumentation scope name of the exported records.
+const otlpScope = "github.com/KaiserGald/logger"
+
+// An OTLPLogRecord represents an entry in the OpenTelemetry log data model. It encodes
+// to the OTLP/JSON form of a LogRecord.
+type OTLPLogRecord struct {
+	TimeUnixNano         string         `json:"timeUnixNano"`
+	ObservedTimeUnixNano string         `json:"observedTimeUnixNano"`
+	SeverityNumber       int            `json:"severityNumber"`
+	SeverityText         string         `json:"severityText"`
+	Body                 OTLPAnyValue   `json:"body"`
+	Attributes           []OTLPKeyValue `json:"attributes,omitempty"`
+	TraceID              string         `json:"traceId,omitempty"`
+	SpanID               string         `json:"spanId,omitempty"`
+}
+
+// An OTLPKeyValue represents an attribute of a log record or resource.
+type OTLPKeyValue struct {
+	Key   string       `json:"key"`
+	Value OTLPAnyValue `json:"value"`
+}
+
+// An OTLPAnyValue represents the value of an attribute. Exactly one of its fields is set.
+// Integers are strings as required by OTLP/JSON.
+type OTLPAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// An OTLPConfig represents the settings of an OTLP exporter.
+type OTLPConfig struct {
+	Endpoint    string     // OTLP/HTTP logs endpoint, http://localhost:4318/v1/logs by default
+	ServiceName string     // the service.name resource attribute
+	Resource    []Field    // other resource attributes, such as service.version
+	HTTP        HTTPConfig // batching, retry and header settings, its URL, Format and Encoder are ignored
+}
+
+// OTLP converts the entry to the OpenTelemetry log data model. Fields become attributes,
+// except for valid trace_id and span_id fields which become the trace context of the
+// record. The caller is sent as code.filepath, code.lineno and code.function, the stack
+// trace as code.stacktrace.
+func (en *Entry) OTLP() OTLPLogRecord {
+	number, text := otlpSeverity(en.Level)
+	r := OTLPLogRecord{
+		TimeUnixNano:         strconv.FormatInt(en.Time.UnixNano(), 10),
+		ObservedTimeUnixNano: strconv.FormatInt(time.Now().UnixNano(), 10),
+		SeverityNumber:       number,
+		SeverityText:         text,
+		Body:                 otlpValue(en.Message),
+	}
+	for _, f := range en.Fields {
+		if s, ok := f.Value.(string); ok {
+			if f.Key == TraceIDKey && validHexID(s, 16) {
+				r.TraceID = s
+				continue
+			}
+			if f.Key == SpanIDKey && validHexID(s, 8) {
+				r.SpanID = s
+				continue
+			}
+		}
+		r.Attributes = append(r.Attributes, OTLPKeyValue{f.Key, otlpValue(f.Value)})
+	}
+	if en.File != "" {
+		r.Attributes = append(r.Attributes,
+			OTLPKeyValue{"code.filepath", otlpValue(en.File)},
+			OTLPKeyValue{"code.lineno", otlpValue(en.Line)})
+		if en.Function != "" {
+			r.Attributes = append(r.Attributes, OTLPKeyValue{"code.function", otlpValue(en.Function)})
+		}
+	}
+	if en.Stack != "" {
+		r.Attributes = append(r.Attributes, OTLPKeyValue{"code.stacktrace", otlpValue(en.Stack)})
+	}
+	return r
+}
+
+// EncodeOTLP encodes an entry as an OTLP/JSON LogRecord.
+func EncodeOTLP(dst []byte, en *Entry) []byte {
+	b, _ := json.Marshal(en.OTLP())
+	return append(dst, b...)
+}
+
+// NewOTLPSink returns a sink that exports entries to an OpenTelemetry collector using
+// OTLP/HTTP with JSON encoding.
+func NewOTLPSink(c OTLPConfig) (*HTTPSink, error) {
+	if c.Endpoint == "" {
+		c.Endpoint = defaultOTLPEndpoint
+	}
+	resource := []OTLPKeyValue{}
+	if c.ServiceName != "" {
+		resource = append(resource, OTLPKeyValue{"service.name", otlpValue(c.ServiceName)})
+	}
+	for _, f := range c.Resource {
+		if f.Key == "" {
+			return nil, errors.New("Invalid resource attribute")
+		}
+		resource = append(resource, OTLPKeyValue{f.Key, otlpValue(f.Value)})
+	}
+
+	head, err := json.Marshal(struct {
+		Attributes []OTLPKeyValue `json:"attributes"`
+	}{resource})
+	if err != nil {
+		return nil, err
+	}
+	scope, _ := json.Marshal(otlpScope)
+
+	h := c.HTTP
+	h.URL = c.Endpoint
+	h.Format = JSONArray
+	h.Encoder = EncodeOTLP
+	s, err := NewHTTPSink(h)
+	if err != nil {
+		return nil, err
+	}
+	s.envelope[0] = []byte(`{"resourceLogs":[{"resource":` + string(head) + `,"scopeLogs":[{"scope":{"name":` + string(scope) + `},"logRecords":`)
+	s.envelope[1] = []byte(`}]}]}`)
+	return s, nil
+}
+
+// otlpSeverity returns the OpenTelemetry severity number and text of a log level.
+func otlpSeverity(level LogLevel) (int, string) {
+	switch level {
+	case All:
+		return 5, "DEBUG"
+	case Verbose:
+		return 9, "INFO"
+	case Normal:
+		return 10, "NOTICE"
+	}
+	return 17, "ERROR"
+}
+
+// otlpValue returns v as an attribute value.
+func otlpValue(v interface{}) OTLPAnyValue {
+	var s string
+	switch x := v.(type) {
+	case string:
+		s = x
+	case bool:
+		return OTLPAnyValue{BoolValue: &x}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		i := fmt.Sprint(x)
+		return OTLPAnyValue{IntValue: &i}
+	case float32:
+		f := float64(x)
+		return OTLPAnyValue{DoubleValue: &f}
+	case float64:
+		return OTLPAnyValue{DoubleValue: &x}
+	case error:
+		s = errorText(x)
+	case fmt.Stringer:
+		s = stringerText(x)
+	default:
+		s = fmt.Sprint(x)
+	}
+	return OTLPAnyValue{StringValue: &s}
+}
+
+// validHexID returns true if s is the lowercase hex encoding of a non-zero ID of n bytes.
+func validHexID(s string, n int) bool {
+	if len(s) != 2*n {
+		return false
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil || hex.EncodeToString(b) != s {
+		return false
+	}
+	for _, c := range b {
+		if c != 0 {
+			return true
+		}
+	}
+	return false
+}
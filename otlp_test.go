@@ -0,0 +1,108 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEntryOTLP(t *testing.T) {
+	en := &Entry{
+		Time:    time.Unix(1517839384, 5),
+		Level:   Normal,
+		Message: "Started",
+		Fields: []Field{
+			{"port", 8080},
+			{TraceIDKey, "4bf92f3577b34da6a3ce929d0e0e4736"},
+			{SpanIDKey, "00f067aa0ba902b7"},
+			{"ok", true},
+		},
+		File: "main.go",
+		Line: 12,
+	}
+	r := en.OTLP()
+	if r.TimeUnixNano != "1517839384000000005" {
+		t.Errorf("Wrong timestamp, expected '%v' got '%v'", "1517839384000000005", r.TimeUnixNano)
+	}
+	if r.SeverityNumber != 10 || r.SeverityText != "NOTICE" {
+		t.Errorf("Wrong severity, expected '%v %v' got '%v %v'", 10, "NOTICE", r.SeverityNumber, r.SeverityText)
+	}
+	if r.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || r.SpanID != "00f067aa0ba902b7" {
+		t.Errorf("Trace context was not set, got '%v' '%v'", r.TraceID, r.SpanID)
+	}
+	b, _ := json.Marshal(r.Attributes)
+	expected := `[{"key":"port","value":{"intValue":"8080"}},{"key":"ok","value":{"boolValue":true}},` +
+		`{"key":"code.filepath","value":{"stringValue":"main.go"}},{"key":"code.lineno","value":{"intValue":"12"}}]`
+	if string(b) != expected {
+		t.Errorf("Wrong attributes, expected '%v' got '%v'", expected, string(b))
+	}
+
+	en.Fields = []Field{{TraceIDKey, "not-a-trace-id"}, {SpanIDKey, "0000000000000000"}}
+	r = en.OTLP()
+	if r.TraceID != "" || r.SpanID != "" || len(r.Attributes) != 4 {
+		t.Errorf("Invalid trace context was not kept as attributes, got '%+v'", r)
+	}
+}
+
+func TestOTLPSink(t *testing.T) {
+	server := &batchServer{}
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	sink, err := NewOTLPSink(OTLPConfig{
+		Endpoint:    srv.URL + "/v1/logs",
+		ServiceName: "api",
+		Resource:    []Field{{"service.version", "1.2.0"}},
+		HTTP:        HTTPConfig{Interval: time.Hour},
+	})
+	if err != nil {
+		t.Fatalf("Error creating OTLP sink: %v", err)
+	}
+	test := New(false, false)
+	defer discardStderr(t)()
+	test.AddSink(sink)
+
+	test.Error.LogFieldsFunc("Failed", func() []Field {
+		return []Field{{TraceIDKey, "4bf92f3577b34da6a3ce929d0e0e4736"}}
+	})
+	if err := sink.Flush(); err != nil {
+		t.Errorf("Error flushing OTLP sink: %v", err)
+	}
+	bodies, _ := server.received()
+	if len(bodies) != 1 {
+		t.Fatalf("Wrong number of requests, expected '%v' got '%v'", 1, len(bodies))
+	}
+
+	var req struct {
+		ResourceLogs []struct {
+			Resource struct {
+				Attributes []OTLPKeyValue
+			}
+			ScopeLogs []struct {
+				LogRecords []OTLPLogRecord
+			}
+		}
+	}
+	if err := json.Unmarshal([]byte(bodies[0]), &req); err != nil {
+		t.Fatalf("Body is not an OTLP request: %v", err)
+	}
+	if len(req.ResourceLogs) != 1 || len(req.ResourceLogs[0].ScopeLogs) != 1 {
+		t.Fatalf("Wrong request structure, got '%v'", bodies[0])
+	}
+	attrs := req.ResourceLogs[0].Resource.Attributes
+	if len(attrs) != 2 || attrs[0].Key != "service.name" || *attrs[0].Value.StringValue != "api" {
+		t.Errorf("Wrong resource attributes, got '%v'", bodies[0])
+	}
+	records := req.ResourceLogs[0].ScopeLogs[0].LogRecords
+	if len(records) != 1 || *records[0].Body.StringValue != "Failed" || records[0].SeverityNumber != 17 ||
+		records[0].TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Wrong log records, got '%v'", bodies[0])
+	}
+	test.Close(time.Second)
+}
@@ -0,0 +1,85 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import "errors"
+
+// A ColorMode represents whether a text output writes colors.
+type ColorMode uint8
+
+// Constants for defining ColorModes.
+const (
+	AutoColor   ColorMode = iota // Colors follow the logger, and for the stderr whether it supports them.
+	AlwaysColor                  // Colors are written even if the logger or the stderr has them off.
+	NeverColor                   // Colors are never written.
+)
+
+// An OutputStyle represents how a text output renders entries. The zero value renders
+// entries with the settings of their events.
+type OutputStyle struct {
+	Color  ColorMode // whether colors are written, events shown without colors never are
+	Format int       // timestamp format flags used instead of those of the events, 0 keeps them
+	Layout string    // time layout used instead of those of the events, see SetLayout
+}
+
+// SetOutputStyle sets how the stderr or the save log renders entries, so an output can
+// have its own colors and timestamps without changing the events. The save log has the
+// style OutputStyle{Color: NeverColor} by default, so it never contains escape sequences.
+// When both are set, Layout is used instead of Format.
+func (l *Logger) SetOutputStyle(name string, st OutputStyle) error {
+	if st.Format != 0 && !validateTimestamp(st.Format) {
+		return errors.New("Invalid format flag combination")
+	}
+	if !validateLayout(st.Layout) {
+		return errors.New("Invalid timestamp layout")
+	}
+	if st.Color > NeverColor {
+		return errors.New("Invalid color mode")
+	}
+	switch name {
+	case StderrOutput:
+		l.stderrStyle = st
+	case FileOutput:
+		l.fileStyle = st
+	default:
+		return errors.New("Output has no text rendering: " + name)
+	}
+	l.resetWidths()
+	return nil
+}
+
+// colorFor returns true if the event is rendered with colors in the style of an output.
+func (e *Event) colorFor(st OutputStyle) bool {
+	switch st.Color {
+	case AlwaysColor:
+		return e.colored
+	case NeverColor:
+		return false
+	}
+	return e.colored && e.Logger.colored
+}
+
+// terminalStyle returns the style of the stderr. Its automatic colors are turned off
+// when color detection found that the stderr does not support them.
+func (l *Logger) terminalStyle() OutputStyle {
+	st := l.stderrStyle
+	if st.Color == AutoColor && l.colorAuto && !l.ttyColor {
+		st.Color = NeverColor
+	}
+	return st
+}
+
+// timeSettings returns the timestamp format flags and time layout of the event in the
+// style of an output. An empty layout selects the format flags.
+func (e *Event) timeSettings(st OutputStyle) (int, string) {
+	switch {
+	case st.Layout != "":
+		return e.format, st.Layout
+	case st.Format != 0:
+		return st.Format, ""
+	}
+	return e.format, e.layout
+}
@@ -0,0 +1,144 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFileOutputWithoutColor(t *testing.T) {
+	dir := t.TempDir()
+	test := New(false, true)
+	test.SetColorLevel(BasicColor)
+	test.Error.SetColorFormat(Timestamp | Prefix | Message | Caller | Highlight)
+	if err := test.SaveLog(dir); err != nil {
+		t.Fatalf("Error saving log: %v", err)
+	}
+	defer discardStderr(t)()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				test.Error.LogFieldsFunc("failed", func() []Field { return []Field{{"n", j}, {"d", time.Second}} })
+			}
+		}()
+	}
+	wg.Wait()
+
+	res, _ := test.Error.Log("colored")
+	if !strings.Contains(res, esc) {
+		t.Errorf("Stderr lost its colors, got '%q'", res)
+	}
+	if !test.Error.colored {
+		t.Errorf("Writing the save log changed the event")
+	}
+	b, _ := os.ReadFile(filepath.Join(dir, "log.log"))
+	if strings.Contains(string(b), "\033") {
+		t.Errorf("Save log contains escape sequences")
+	}
+	if n := strings.Count(string(b), "\n"); n != 161 {
+		t.Errorf("Wrong number of lines, expected '%v' got '%v'", 161, n)
+	}
+}
+
+func TestLoggerSetOutputStyle(t *testing.T) {
+	dir := t.TempDir()
+	test := New(true, true)
+	test.SetColorLevel(BasicColor)
+	test.SetClock(ClockFunc(func() time.Time { return time.Date(2018, 2, 5, 14, 3, 4, 0, time.UTC) }))
+	test.SaveLog(dir)
+	defer discardStderr(t)()
+
+	if err := test.SetOutputStyle(StderrOutput, OutputStyle{Color: NeverColor, Format: Time24Hour}); err != nil {
+		t.Errorf("Error setting output style: %v", err)
+	}
+	if err := test.SetOutputStyle(FileOutput, OutputStyle{Color: NeverColor, Layout: RFC3339}); err != nil {
+		t.Errorf("Error setting output style: %v", err)
+	}
+	res, _ := test.Error.Log("abc")
+	if expected := "14:03:04 - ERROR: abc\n"; res != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, res)
+	}
+	b, _ := os.ReadFile(filepath.Join(dir, "log.log"))
+	if expected := "2018-02-05T14:03:04Z - ERROR: abc\n"; string(b) != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, string(b))
+	}
+	if test.Error.layout != "" || test.Error.format != ShortDate|Time12Hour|TimeZone {
+		t.Errorf("Output style changed the event")
+	}
+
+	test.SetOutputStyle(FileOutput, OutputStyle{})
+	test.Error.Log("abc")
+	b, _ = os.ReadFile(filepath.Join(dir, "log.log"))
+	if !strings.Contains(string(b), esc+"31mERROR:"+clear) {
+		t.Errorf("Colors were not written to the save log, got '%q'", string(b))
+	}
+}
+
+func TestOutputStyleColor(t *testing.T) {
+	dir := t.TempDir()
+	test := New(false)
+	test.ShowCaller(false)
+	test.SetColorLevel(BasicColor)
+	// detection found that the stderr is not a terminal
+	test.colorAuto, test.ttyColor = true, false
+	test.SaveLog(dir)
+	defer discardStderr(t)()
+
+	test.SetOutputStyle(FileOutput, OutputStyle{})
+	if res, _ := test.Error.Log("auto"); res != "ERROR: auto\n" {
+		t.Errorf("Colors written to a stderr without color support, got '%q'", res)
+	}
+	b, _ := os.ReadFile(filepath.Join(dir, "log.log"))
+	if expected := esc + "31mERROR:" + clear + " auto\n"; string(b) != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, string(b))
+	}
+
+	test.ShowColor(false)
+	test.SetOutputStyle(StderrOutput, OutputStyle{Color: AlwaysColor})
+	test.SetOutputStyle(FileOutput, OutputStyle{Color: AlwaysColor})
+	if res, _ := test.Error.Log("always"); res != esc+"31mERROR:"+clear+" always\n" {
+		t.Errorf("Colors were not forced on the stderr, got '%q'", res)
+	}
+	b, _ = os.ReadFile(filepath.Join(dir, "log.log"))
+	if !strings.HasSuffix(string(b), esc+"31mERROR:"+clear+" always\n") {
+		t.Errorf("Colors were not forced on the save log, got '%q'", string(b))
+	}
+
+	test.SetOutputStyle(StderrOutput, OutputStyle{Color: NeverColor})
+	test.ShowColor(true)
+	if res, _ := test.Error.Log("never"); res != "ERROR: never\n" {
+		t.Errorf("Colors written with NeverColor, got '%q'", res)
+	}
+}
+
+func TestLoggerSetOutputStyleInvalid(t *testing.T) {
+	test := New()
+	invalid := map[string]OutputStyle{
+		StderrOutput: {Format: ShortDate | LongDate},
+		FileOutput:   {Layout: "nothing"},
+		"network":    {},
+	}
+	for name, st := range invalid {
+		if err := test.SetOutputStyle(name, st); err == nil {
+			t.Errorf("Invalid style '%+v' for '%v' did not trigger error", st, name)
+		}
+	}
+	if err := test.SetOutputStyle(StderrOutput, OutputStyle{Color: NeverColor + 1}); err == nil {
+		t.Errorf("Invalid color mode did not trigger error")
+	}
+	if test.stderrStyle != (OutputStyle{}) || test.fileStyle != (OutputStyle{Color: NeverColor}) {
+		t.Errorf("Invalid output style was set")
+	}
+}
@@ -0,0 +1,104 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"unicode"
+)
+
+// maxPooledBuffer is the largest buffer capacity kept in the pool. Larger buffers, for
+// example from entries with long stack traces, are left for the garbage collector.
+const maxPooledBuffer = 64 << 10
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, 256)
+		return &b
+	},
+}
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *[]byte {
+	b := bufferPool.Get().(*[]byte)
+	*b = (*b)[:0]
+	return b
+}
+
+// putBuffer returns a buffer to the pool.
+func putBuffer(b *[]byte) {
+	if cap(*b) > maxPooledBuffer {
+		return
+	}
+	bufferPool.Put(b)
+}
+
+// appendFields appends fields to dst as space separated key=value pairs.
+func appendFields(dst []byte, fields []Field) []byte {
+	for _, f := range fields {
+		dst = append(dst, ' ')
+		dst = append(dst, f.Key...)
+		dst = append(dst, '=')
+		dst = appendValue(dst, f.Value)
+	}
+	return dst
+}
+
+// stringerText returns the text of s. A nil pointer is formatted by fmt.Sprint, like
+// errorText does for errors.
+func stringerText(s fmt.Stringer) string {
+	if isNilPointer(s) {
+		return fmt.Sprint(s)
+	}
+	return s.String()
+}
+
+// appendValue appends a field value to dst, quoting it if it contains spaces, quotes,
+// equal signs or unprintable characters.
+func appendValue(dst []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case string:
+		return appendString(dst, v)
+	case error:
+		return appendString(dst, errorText(v))
+	case fmt.Stringer:
+		return appendString(dst, stringerText(v))
+	case int:
+		return strconv.AppendInt(dst, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(dst, v, 10)
+	case uint64:
+		return strconv.AppendUint(dst, v, 10)
+	case float64:
+		return strconv.AppendFloat(dst, v, 'g', -1, 64)
+	case bool:
+		return strconv.AppendBool(dst, v)
+	}
+	return appendString(dst, fmt.Sprint(v))
+}
+
+// appendString appends s to dst, quoting it if needed.
+func appendString(dst []byte, s string) []byte {
+	if needsQuote(s) {
+		return strconv.AppendQuote(dst, s)
+	}
+	return append(dst, s...)
+}
+
+// needsQuote returns true if s cannot be shown as a field value without quotes.
+func needsQuote(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r == ' ' || r == '=' || r == '"' || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
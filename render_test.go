@@ -0,0 +1,103 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/logrusorgru/aurora"
+)
+
+// discardStderr points os.Stderr at the null device until the returned function is
+// called.
+func discardStderr(tb testing.TB) func() {
+	null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		tb.Fatalf("Error opening %v: %v", os.DevNull, err)
+	}
+	stderr := os.Stderr
+	os.Stderr = null
+	return func() {
+		os.Stderr = stderr
+		null.Close()
+	}
+}
+
+func TestAppendColorStart(t *testing.T) {
+	colors := []Color{
+		GreenFg,
+		Bold | MagentaFg | GrayBg,
+		Inverse | RedBg,
+		Bold | Inverse,
+		BlackFg | BlackBg,
+	}
+	for _, c := range colors {
+		expected := esc + aurora.Color(c).Nos() + "m"
+		actual := string(appendColorStart(nil, c))
+		if actual != expected {
+			t.Errorf("Color codes do not match, expected '%q' got '%q'", expected, actual)
+		}
+	}
+	if len(appendColorStart(nil, 0)) != 0 || len(appendColorEnd(nil, 0)) != 0 {
+		t.Errorf("Empty color produced escape codes")
+	}
+}
+
+func BenchmarkEventLogDisabled(b *testing.B) {
+	test := New()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		test.Debug.Log("Test message %v", i)
+	}
+}
+
+func BenchmarkEventLog(b *testing.B) {
+	defer discardStderr(b)()
+	test := New(true, true)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		test.Error.Log("Test message")
+	}
+}
+
+func BenchmarkEventLogArgs(b *testing.B) {
+	defer discardStderr(b)()
+	test := New(true, true)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		test.Error.Log("Test message %v %v", i, "arg")
+	}
+}
+
+func BenchmarkEventLogNoColor(b *testing.B) {
+	defer discardStderr(b)()
+	test := New(true, false)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		test.Error.Log("Test message")
+	}
+}
+
+func TestAppendFields(t *testing.T) {
+	fields := []Field{
+		{"str", "value"},
+		{"quoted", "two words"},
+		{"empty", ""},
+		{"int", 42},
+		{"float", 1.5},
+		{"bool", true},
+		{"err", errors.New("failed: x=1")},
+		{"dur", 1500 * time.Millisecond},
+	}
+	expected := ` str=value quoted="two words" empty="" int=42 float=1.5 bool=true err="failed: x=1" dur=1.5s`
+	actual := string(appendFields(nil, fields))
+	if actual != expected {
+		t.Errorf("Fields do not match, expected '%v' got '%v'", expected, actual)
+	}
+}
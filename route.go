@@ -0,0 +1,255 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Names of the built in outputs of a Logger, used in routes.
+const (
+	StderrOutput = "stderr" // the stderr
+	FileOutput   = "file"   // the save log, see SaveLog
+)
+
+// Bits of the built in outputs in an output set. Sink i is bit i+2.
+const (
+	stderrBit uint64 = 1 << iota
+	fileBit
+	firstSinkBit
+)
+
+// maxNamedSinks is the number of sinks that fit in an output set.
+const maxNamedSinks = 62
+
+// allOutputs is the output set of a logger without routes.
+const allOutputs = ^uint64(0)
+
+// A Route represents a rule sending the entries it matches to a set of outputs.
+// Outputs are StderrOutput, FileOutput and the names of sinks added with AddNamedSink.
+// Fields are matched against every field passed to the sinks: those given to LogFields
+// or LogFieldsFunc, the trace_id and span_id of LogContext, and the error chain.
+type Route struct {
+	Levels []LogLevel // levels of the matched entries, every level when empty, see AtLeast
+	Fields []Field    // fields the matched entries must have, values are compared as formatted by fmt.Sprint
+	To     []string   // names of the outputs receiving the matched entries
+}
+
+// AtLeast returns lv and every level above it, for the Levels of a Route.
+func AtLeast(lv LogLevel) []LogLevel {
+	var levels []LogLevel
+	for ; lv <= ErrorsOnly; lv++ {
+		levels = append(levels, lv)
+	}
+	return levels
+}
+
+// A router represents compiled routes. Routes without fields are merged into an output
+// set per level, so only routes with fields are evaluated for each entry.
+type router struct {
+	levels   [ErrorsOnly + 1]uint64
+	matchers []matcher
+}
+
+// A matcher represents a compiled route with fields.
+type matcher struct {
+	levels uint8 // bit per level
+	fields []match
+	to     uint64
+}
+
+// A match represents a field a matched entry must have.
+type match struct {
+	key   string
+	value string
+}
+
+// AddNamedSink adds a sink that can be named in routes. Until routes are set it receives
+// every entry of the logger, like the sinks added with AddSink.
+func (l *Logger) AddNamedSink(name string, s Sink) error {
+	if name == "" || name == StderrOutput || name == FileOutput {
+		return errors.New("Invalid sink name: " + name)
+	}
+	for _, n := range l.sinkNames {
+		if n == name {
+			return errors.New("Duplicate sink name: " + name)
+		}
+	}
+	if len(l.sinks) >= maxNamedSinks {
+		return errors.New("Too many sinks")
+	}
+	l.sinks = append(l.sinks, s)
+	l.sinkNames = append(l.sinkNames, name)
+	l.updateLevels()
+	return nil
+}
+
+// SetRoutes replaces the outputs of entries with routes. An entry is written to the
+// outputs of every route it matches, and to no output when it matches none. Sinks added
+// with AddSink are not named and keep receiving every entry. Set routes after adding the
+// sinks they name. Without arguments, SetRoutes restores writing every entry everywhere.
+func (l *Logger) SetRoutes(routes ...Route) error {
+	if len(routes) == 0 {
+		l.routes = nil
+		return nil
+	}
+	r := &router{}
+	for i, route := range routes {
+		to, err := l.outputSet(route.To)
+		if err != nil {
+			return fmt.Errorf("Invalid route %d: %v", i, err)
+		}
+		var levels uint8
+		for _, lv := range route.Levels {
+			if lv > ErrorsOnly {
+				return fmt.Errorf("Invalid route %d: invalid level %d", i, lv)
+			}
+			levels |= 1 << lv
+		}
+		if len(route.Levels) == 0 {
+			levels = 1<<(ErrorsOnly+1) - 1
+		}
+
+		if len(route.Fields) == 0 {
+			for lv := range r.levels {
+				if levels&(1<<lv) != 0 {
+					r.levels[lv] |= to
+				}
+			}
+			continue
+		}
+		m := matcher{levels: levels, to: to}
+		for _, f := range route.Fields {
+			m.fields = append(m.fields, match{f.Key, fmt.Sprint(f.Value)})
+		}
+		r.matchers = append(r.matchers, m)
+	}
+	l.routes = r
+	return nil
+}
+
+// outputSet returns the output set of the given output names.
+func (l *Logger) outputSet(names []string) (uint64, error) {
+	var set uint64
+	for _, name := range names {
+		switch name {
+		case StderrOutput:
+			set |= stderrBit
+			continue
+		case FileOutput:
+			set |= fileBit
+			continue
+		}
+		i := l.sinkIndex(name)
+		if i < 0 {
+			return 0, errors.New("unknown output " + name)
+		}
+		set |= firstSinkBit << uint(i)
+	}
+	return set, nil
+}
+
+// sinkIndex returns the index of the sink with the given name, or -1.
+func (l *Logger) sinkIndex(name string) int {
+	for i, n := range l.sinkNames {
+		if n == name && name != "" {
+			return i
+		}
+	}
+	return -1
+}
+
+// outputs returns the output set of an entry of the given level, as far as the minimum
+// levels of the outputs allow. fields are the groups of fields of the entry, such as
+// those passed to LogFieldsFunc and those of its trace context.
+func (l *Logger) outputs(level LogLevel, fields ...[]Field) uint64 {
+	return l.routeOutputs(level, fields) & l.levelOutputs(level)
+}
+
+// routeOutputs returns the outputs of the routes matching an entry.
+func (l *Logger) routeOutputs(level LogLevel, fields [][]Field) uint64 {
+	r := l.routes
+	if r == nil {
+		return allOutputs
+	}
+	set := r.levels[level] | l.unnamedSinks()
+	if len(r.matchers) == 0 {
+		return set
+	}
+	for i := range r.matchers {
+		m := &r.matchers[i]
+		if m.levels&(1<<level) != 0 && set&m.to != m.to && m.matches(fields) {
+			set |= m.to
+		}
+	}
+	return set
+}
+
+// unnamedSinks returns the output set of the sinks added with AddSink, which are not
+// subject to routes.
+func (l *Logger) unnamedSinks() uint64 {
+	var set uint64
+	for i, name := range l.sinkNames {
+		if name == "" && i < maxNamedSinks {
+			set |= firstSinkBit << uint(i)
+		}
+	}
+	return set
+}
+
+// routed returns true if the sink at index i receives entries of the output set.
+func (l *Logger) routed(set uint64, i int) bool {
+	return i >= maxNamedSinks || set&(firstSinkBit<<uint(i)) != 0
+}
+
+// dropSinks removes the sinks from the output sets, after the sinks were closed.
+func (r *router) dropSinks() {
+	for lv := range r.levels {
+		r.levels[lv] &= stderrBit | fileBit
+	}
+	for i := range r.matchers {
+		r.matchers[i].to &= stderrBit | fileBit
+	}
+}
+
+// sinkRouted returns true if a sink receives entries of the output set.
+func (l *Logger) sinkRouted(set uint64) bool {
+	for i := range l.sinks {
+		if l.routed(set, i) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns true if the groups of fields together contain every field of the
+// matcher.
+func (m *matcher) matches(groups [][]Field) bool {
+	for _, want := range m.fields {
+		found := false
+		for _, fields := range groups {
+			for _, f := range fields {
+				if f.Key == want.key && fieldString(f.Value) == want.value {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldString returns a field value as formatted by fmt.Sprint.
+func fieldString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
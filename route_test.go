@@ -0,0 +1,172 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// captureStderr redirects the stderr to a file until the returned function is called,
+// which returns what was written.
+func captureStderr(tb testing.TB) func() string {
+	f, err := os.CreateTemp(tb.TempDir(), "stderr")
+	if err != nil {
+		tb.Fatalf("Error creating stderr file: %v", err)
+	}
+	stderr := os.Stderr
+	os.Stderr = f
+	return func() string {
+		os.Stderr = stderr
+		f.Close()
+		b, _ := os.ReadFile(f.Name())
+		return string(b)
+	}
+}
+
+func TestLoggerSetRoutes(t *testing.T) {
+	dir := t.TempDir()
+	test := New(false, false)
+	if err := test.SaveLog(dir); err != nil {
+		t.Fatalf("Error saving log: %v", err)
+	}
+	test.SetLogLevel(All)
+	network := &memorySink{}
+	all := &memorySink{}
+	db, err := NewFileSink(filepath.Join(dir, "db.log"), nil)
+	if err != nil {
+		t.Fatalf("Error creating file sink: %v", err)
+	}
+	if err := test.AddNamedSink("network", network); err != nil {
+		t.Errorf("Error adding sink: %v", err)
+	}
+	if err := test.AddNamedSink("db", db); err != nil {
+		t.Errorf("Error adding sink: %v", err)
+	}
+	test.AddSink(all)
+
+	err = test.SetRoutes(
+		Route{Levels: AtLeast(ErrorsOnly), To: []string{FileOutput, "network"}},
+		Route{Levels: []LogLevel{All}, To: []string{StderrOutput}},
+		Route{Fields: []Field{{"component", "db"}}, To: []string{"db"}},
+	)
+	if err != nil {
+		t.Fatalf("Error setting routes: %v", err)
+	}
+
+	stderr := captureStderr(t)
+	test.Error.Log("failed")
+	test.Debug.Log("details")
+	test.Info.Log("dropped")
+	test.Notice.LogFieldsFunc("query", func() []Field { return []Field{{"component", "db"}, {"rows", 3}} })
+	output := stderr()
+
+	if expected := "DEBUG: details\n"; output != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, output)
+	}
+	b, _ := os.ReadFile(filepath.Join(dir, "log.log"))
+	if expected := "ERROR: failed\n"; string(b) != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, string(b))
+	}
+	if len(network.entries) != 1 || network.entries[0].Message != "failed" {
+		t.Errorf("Wrong entries routed to the network sink, got '%v'", network.messages())
+	}
+	if len(all.entries) != 4 {
+		t.Errorf("Unnamed sink did not receive every entry, got '%v'", all.messages())
+	}
+	test.Close(0)
+	b, _ = os.ReadFile(filepath.Join(dir, "db.log"))
+	if !strings.HasSuffix(string(b), " NOTICE: query component=db rows=3\n") || strings.Count(string(b), "\n") != 1 {
+		t.Errorf("Wrong entries routed to the db file, got '%q'", string(b))
+	}
+}
+
+func TestLoggerSetRoutesInvalid(t *testing.T) {
+	test := New(false, false)
+	if err := test.AddNamedSink(StderrOutput, &memorySink{}); err == nil {
+		t.Errorf("Reserved sink name did not trigger error")
+	}
+	if err := test.AddNamedSink("", &memorySink{}); err == nil {
+		t.Errorf("Empty sink name did not trigger error")
+	}
+	test.AddNamedSink("network", &memorySink{})
+	if err := test.AddNamedSink("network", &memorySink{}); err == nil {
+		t.Errorf("Duplicate sink name did not trigger error")
+	}
+
+	invalid := [][]Route{
+		{{To: []string{"missing"}}},
+		{{Levels: []LogLevel{ErrorsOnly + 1}, To: []string{StderrOutput}}},
+	}
+	for _, routes := range invalid {
+		if err := test.SetRoutes(routes...); err == nil {
+			t.Errorf("Invalid routes '%+v' did not trigger error", routes)
+		}
+	}
+	if test.routes != nil {
+		t.Errorf("Invalid routes were set")
+	}
+}
+
+func TestLoggerRouteMergedFields(t *testing.T) {
+	defer discardStderr(t)()
+	test := New(false, false)
+	traced := &memorySink{}
+	db := &memorySink{}
+	test.AddNamedSink("traced", traced)
+	test.AddNamedSink("db", db)
+	tc := TraceContext{"4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", true}
+	test.SetRoutes(
+		Route{Fields: []Field{{TraceIDKey, tc.TraceID}}, To: []string{"traced"}},
+		Route{Fields: []Field{{"component", "db"}}, To: []string{"db"}},
+	)
+
+	test.Error.LogContext(ContextWithTrace(context.Background(), tc), "traced")
+	test.Error.Log("untraced")
+	test.Error.LogFields("query", Field{"component", "db"}, Field{"rows", 3})
+
+	if actual := traced.messages(); len(actual) != 1 || actual[0] != "traced" {
+		t.Errorf("Route did not match the trace fields, got '%v'", actual)
+	}
+	if actual := db.messages(); len(actual) != 1 || actual[0] != "query" {
+		t.Errorf("Route did not match the fields of LogFields, got '%v'", actual)
+	}
+}
+
+func TestLoggerOutputs(t *testing.T) {
+	test := New(false, false)
+	if set := test.outputs(All, nil); set != allOutputs {
+		t.Errorf("Logger without routes does not write everywhere, got '%b'", set)
+	}
+	test.SetRoutes(
+		Route{Levels: AtLeast(Normal), To: []string{StderrOutput}},
+		Route{Levels: []LogLevel{ErrorsOnly}, Fields: []Field{{"code", 500}}, To: []string{FileOutput}},
+	)
+	tests := []struct {
+		level    LogLevel
+		fields   []Field
+		expected uint64
+	}{
+		{All, nil, 0},
+		{Normal, nil, stderrBit},
+		{ErrorsOnly, []Field{{"code", 500}}, stderrBit | fileBit},
+		{ErrorsOnly, []Field{{"code", "500"}}, stderrBit | fileBit},
+		{ErrorsOnly, []Field{{"code", 404}}, stderrBit},
+		{Normal, []Field{{"code", 500}}, stderrBit},
+	}
+	for _, tt := range tests {
+		if set := test.outputs(tt.level, tt.fields); set != tt.expected {
+			t.Errorf("Output sets do not match for '%v %v', expected '%b' got '%b'", tt.level, tt.fields, tt.expected, set)
+		}
+	}
+	test.SetRoutes()
+	if test.routes != nil {
+		t.Errorf("Routes were not cleared")
+	}
+}
@@ -0,0 +1,90 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"fmt"
+	"time"
+)
+
+// A Sink represents an additional output of a Logger, such as syslog or a network
+// service. Sinks receive the entries of enabled log events that their output level and
+// the routes of the logger send to them, see SetOutputLevel and SetRoutes. When the
+// logger is asynchronous, Write is called from the background goroutine.
+type Sink interface {
+	Write(en *Entry) error
+	Close() error
+}
+
+// An Entry represents a logged message as passed to a Sink.
+type Entry struct {
+	Time     time.Time
+	Level    LogLevel // level of the event, see Event.Level
+	Prefix   string
+	Message  string
+	Fields   []Field // fields of the message, followed by the error chain if it is shown
+	File     string  // file of the caller, empty when callers are not shown
+	Line     int
+	Function string
+	Stack    string // indented stack trace, empty when stack traces are not shown
+}
+
+// AddSink adds an output that receives every entry of the logger, regardless of routes.
+// Sinks are closed by Logger.Close.
+func (l *Logger) AddSink(s Sink) {
+	l.sinks = append(l.sinks, s)
+	l.sinkNames = append(l.sinkNames, "")
+	l.updateLevels()
+}
+
+// closeSinks closes every sink of the logger and returns the first error.
+func (l *Logger) closeSinks() error {
+	var first error
+	for _, s := range l.sinks {
+		if err := s.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	l.sinks = nil
+	l.sinkNames = nil
+	if l.routes != nil {
+		l.routes.dropSinks()
+	}
+	if l.levels != nil {
+		for name := range l.levels.names {
+			if name != StderrOutput && name != FileOutput {
+				delete(l.levels.names, name)
+			}
+		}
+		l.updateLevels()
+	}
+	return first
+}
+
+// newEntry returns the Entry passed to sinks for a call to Log.
+func (e *Event) newEntry(en *entry, chain []Field, stack string) *Entry {
+	out := &Entry{
+		Time:    en.time,
+		Level:   e.Level(),
+		Prefix:  e.Prefix(),
+		Message: fmt.Sprintf(en.fstring, en.args...),
+		Stack:   stack,
+	}
+	var trace []Field
+	if en.trace.TraceID != "" {
+		trace = en.trace.Fields()
+	}
+	if n := len(en.fields) + len(trace) + len(chain); n != 0 {
+		out.Fields = make([]Field, 0, n)
+		out.Fields = append(append(append(out.Fields, en.fields...), trace...), chain...)
+	}
+	if en.caller != nil {
+		out.File = en.caller.file
+		out.Line = en.caller.line
+		out.Function = en.caller.function
+	}
+	return out
+}
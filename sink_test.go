@@ -0,0 +1,98 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memorySink keeps the entries written to it.
+type memorySink struct {
+	mu      sync.Mutex
+	entries []*Entry
+	closed  bool
+	err     error
+}
+
+func (s *memorySink) Write(en *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, en)
+	return s.err
+}
+
+func (s *memorySink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *memorySink) messages() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []string
+	for _, en := range s.entries {
+		out = append(out, en.Message)
+	}
+	return out
+}
+
+func TestLoggerAddSink(t *testing.T) {
+	defer discardStderr(t)()
+	test := New(false, false)
+	now := time.Date(2018, 2, 5, 14, 3, 4, 0, time.UTC)
+	test.SetClock(ClockFunc(func() time.Time { return now }))
+	test.ShowCaller(true)
+	test.Error.ShowErrorChain(true)
+	sink := &memorySink{}
+	test.AddSink(sink)
+
+	err := fmt.Errorf("outer: %w", errors.New("inner"))
+	test.Error.Log("Failed %v: %v", 3, err)
+	test.Debug.Log("Hidden")
+
+	if len(sink.entries) != 1 {
+		t.Fatalf("Wrong number of entries, expected '%v' got '%v'", 1, len(sink.entries))
+	}
+	en := sink.entries[0]
+	if en.Message != "Failed 3: outer: inner" || en.Level != ErrorsOnly || en.Prefix != "ERROR:" || !en.Time.Equal(now) {
+		t.Errorf("Entry does not match the logged message, got '%+v'", en)
+	}
+	if en.Line == 0 || en.Function == "" || en.File == "" {
+		t.Errorf("Caller was not set, got '%+v'", en)
+	}
+	if expected := errorChainFields(err); !reflect.DeepEqual(en.Fields, expected) {
+		t.Errorf("Fields do not match, expected '%v' got '%v'", expected, en.Fields)
+	}
+
+	sink.err = errors.New("broken")
+	if _, err := test.Error.Log("Again"); err == nil {
+		t.Errorf("Sink error was not returned")
+	}
+
+	if err := test.Close(time.Second); err != nil {
+		t.Errorf("Error closing logger: %v", err)
+	}
+	if !sink.closed {
+		t.Errorf("Sink was not closed")
+	}
+}
+
+func TestEventLevel(t *testing.T) {
+	test := New()
+	expected := []LogLevel{All, Verbose, Normal, ErrorsOnly}
+	for i, e := range test.events() {
+		if e.Level() != expected[i] {
+			t.Errorf("Level of %v does not match, expected '%v' got '%v'", e.Prefix(), expected[i], e.Level())
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// stackIndent is placed in front of every line of a stack trace. Tabs in stack traces are
+// replaced with it so that traces are indented the same on every terminal.
+const stackIndent = "    "
+
+// maxStackDepth is the maximum number of frames captured for a stack trace.
+const maxStackDepth = 64
+
+// ShowStack sets whether or not to append a stack trace to entries of this log event.
+// If one of the arguments to Log is an error carrying its own stack trace, that trace
+// is shown instead of the stack of the goroutine calling Log.
+func (e *Event) ShowStack(b bool) {
+	e.stack = b
+}
+
+// stackTrace returns the indented stack trace to append to an entry, or an empty string
+// if stack traces are not shown for this event. depth is the number of frames above
+// stackTrace itself where the trace should start.
+func (e *Event) stackTrace(depth int, a []interface{}) string {
+	if !e.stack {
+		return ""
+	}
+	for _, arg := range a {
+		if err, ok := arg.(error); ok {
+			if trace, ok := stackOf(err); ok {
+				return indentStack(trace)
+			}
+		}
+	}
+
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(depth+2+e.Logger.callerSkip, pcs)
+	return indentStack(formatFrames(pcs[:n]))
+}
+
+// stackOf returns the stack trace carried by err or by any error it wraps. The deepest
+// trace in the chain is used since it is closest to where the error originated.
+func stackOf(err error) (string, bool) {
+	var trace string
+	found := -1
+	walkErrors(err, 0, func(err error, depth int) {
+		if depth <= found {
+			return
+		}
+		if s, ok := ownStack(err); ok {
+			trace = s
+			found = depth
+		}
+	})
+	return trace, found >= 0
+}
+
+// ownStack returns the stack trace of err if it has a StackTrace method. The result of
+// StackTrace may be a string, a []byte, a []uintptr of program counters, or any value
+// that formats itself with %+v like the StackTrace type of github.com/pkg/errors.
+func ownStack(err error) (string, bool) {
+	if isNilPointer(err) {
+		return "", false
+	}
+	m := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return "", false
+	}
+	switch st := m.Call(nil)[0].Interface().(type) {
+	case string:
+		return st, st != ""
+	case []byte:
+		return string(st), len(st) != 0
+	case []uintptr:
+		return formatFrames(st), len(st) != 0
+	case nil:
+		return "", false
+	default:
+		s := fmt.Sprintf("%+v", st)
+		return s, strings.TrimSpace(s) != ""
+	}
+}
+
+// walkErrors calls fn for err and every error in its chain, following both
+// Unwrap() error and Unwrap() []error.
+func walkErrors(err error, depth int, fn func(err error, depth int)) {
+	if err == nil {
+		return
+	}
+	fn(err, depth)
+	if isNilPointer(err) {
+		// a nil pointer wraps nothing, and its Unwrap method may panic
+		return
+	}
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		walkErrors(x.Unwrap(), depth+1, fn)
+	case interface{ Unwrap() []error }:
+		for _, err := range x.Unwrap() {
+			walkErrors(err, depth+1, fn)
+		}
+	}
+}
+
+// formatFrames renders program counters in the same layout as runtime/debug.Stack.
+func formatFrames(pcs []uintptr) string {
+	var lines []string
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		lines = append(lines, frame.Function, "\t"+frame.File+":"+strconv.Itoa(frame.Line))
+		if !more {
+			break
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indentStack indents every line of trace and replaces its tabs with spaces.
+func indentStack(trace string) string {
+	var out string
+	if trace == "" {
+		return out
+	}
+	for _, line := range strings.Split(strings.Trim(trace, "\n"), "\n") {
+		line = strings.Replace(line, "\t", stackIndent, -1)
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		out += stackIndent + line + "\n"
+	}
+	return out
+}
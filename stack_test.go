@@ -0,0 +1,112 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type stackError struct {
+	msg   string
+	trace string
+}
+
+func (e *stackError) Error() string      { return e.msg }
+func (e *stackError) StackTrace() string { return e.trace }
+
+func TestEventShowStack(t *testing.T) {
+	test := New()
+	test.Error.ShowStack(true)
+	if !test.Error.stack {
+		t.Errorf("Stack flag was not properly set, expected '%v' got '%v'", true, test.Error.stack)
+	}
+
+	test.Error.ShowStack(false)
+	if test.Error.stack {
+		t.Errorf("Stack flag was not properly set, expected '%v' got '%v'", false, test.Error.stack)
+	}
+}
+
+func TestEventLogStack(t *testing.T) {
+	test := New(false, false)
+	res, err := test.Error.Log("Test message")
+	if err != nil {
+		t.Errorf("Error building string: %v", err)
+	}
+	if strings.Count(res, "\n") != 1 {
+		t.Errorf("Stack trace shown when disabled, got '%v'", res)
+	}
+
+	test.Error.ShowStack(true)
+	res, err = test.Error.Log("Test message")
+	if err != nil {
+		t.Errorf("Error building string: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(res, "\n"), "\n")
+	if len(lines) < 3 {
+		t.Fatalf("Stack trace missing, got '%v'", res)
+	}
+	expected := stackIndent + "github.com/KaiserGald/logger.TestEventLogStack"
+	if lines[1] != expected {
+		t.Errorf("Stack does not start at caller, expected '%v' got '%v'", expected, lines[1])
+	}
+	for _, line := range lines[1:] {
+		if !strings.HasPrefix(line, stackIndent) || strings.Contains(line, "\t") {
+			t.Errorf("Stack line not indented correctly: '%v'", line)
+		}
+	}
+}
+
+func TestEventLogErrorStack(t *testing.T) {
+	test := New(false, false)
+	test.Error.ShowStack(true)
+	inner := &stackError{"inner", "main.origin\n\t/src/main.go:10"}
+	outer := fmt.Errorf("outer: %w", inner)
+	res, err := test.Error.Log("Failed: %v", outer)
+	if err != nil {
+		t.Errorf("Error building string: %v", err)
+	}
+	expected := "ERROR: Failed: outer: inner\n" +
+		stackIndent + "main.origin\n" +
+		stackIndent + stackIndent + "/src/main.go:10\n"
+	if res != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, res)
+	}
+}
+
+func TestStackOf(t *testing.T) {
+	if _, ok := stackOf(errors.New("plain")); ok {
+		t.Errorf("Plain error reported a stack trace")
+	}
+
+	shallow := &stackError{"shallow", "shallow trace"}
+	deep := &stackError{"deep", "deep trace"}
+	joined := errors.Join(errors.New("other"), fmt.Errorf("wrap: %w", deep))
+	err := fmt.Errorf("%w %w", shallow, joined)
+	trace, ok := stackOf(err)
+	if !ok {
+		t.Fatalf("Stack trace not found in error chain")
+	}
+	if trace != "deep trace" {
+		t.Errorf("Wrong stack trace, expected '%v' got '%v'", "deep trace", trace)
+	}
+
+	var nilErr *stackError
+	if _, ok := stackOf(fmt.Errorf("wrap: %w", nilErr)); ok {
+		t.Errorf("Nil error reported a stack trace")
+	}
+}
+
+func TestIndentStack(t *testing.T) {
+	expected := stackIndent + "main.main\n" + stackIndent + stackIndent + "/src/main.go:3\n"
+	actual := indentStack("\nmain.main\n\t/src/main.go:3\n\n")
+	if actual != expected {
+		t.Errorf("Stack not indented, expected '%v' got '%v'", expected, actual)
+	}
+}
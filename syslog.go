@@ -0,0 +1,306 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The Facility type represents the syslog facility of a SyslogSink.
+type Facility uint8
+
+// Syslog facilities, as defined by RFC 5424.
+const (
+	FacilityKern Facility = iota
+	FacilityUser
+	FacilityMail
+	FacilityDaemon
+	FacilityAuth
+	FacilitySyslog
+	FacilityLPR
+	FacilityNews
+	FacilityUUCP
+	FacilityCron
+	FacilityAuthPriv
+	FacilityFTP
+)
+
+// Syslog facilities for local use.
+const (
+	FacilityLocal0 Facility = 16 + iota
+	FacilityLocal1
+	FacilityLocal2
+	FacilityLocal3
+	FacilityLocal4
+	FacilityLocal5
+	FacilityLocal6
+	FacilityLocal7
+)
+
+// The SyslogFormat type represents the message format of a SyslogSink.
+type SyslogFormat uint8
+
+// Syslog message formats.
+const (
+	RFC5424 SyslogFormat = iota // the current syslog protocol, fields are sent as structured data
+	RFC3164                     // the BSD syslog protocol, fields are appended to the message
+)
+
+// syslogSDID is the structured data ID fields are sent under. 32473 is the private
+// enterprise number reserved for documentation.
+const syslogSDID = "fields@32473"
+
+// localSyslogPaths are tried in order when no address is given.
+var localSyslogPaths = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// A SyslogSink represents a connection to a syslog server.
+type SyslogSink struct {
+	mu       sync.Mutex
+	network  string
+	address  string
+	facility Facility
+	app      string
+	hostname string
+	format   SyslogFormat
+	conn     net.Conn
+}
+
+// NewSyslogSink connects to the syslog server at address. network is "unix" or
+// "unixgram" for a local socket, "udp" or "tcp". Messages sent over TCP use octet
+// counting framing. An empty network and address connect to the local syslog socket,
+// such as /dev/log. app is the name the messages are sent under.
+func NewSyslogSink(network, address string, facility Facility, app string) (*SyslogSink, error) {
+	switch network {
+	case "", "unix", "unixgram", "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6":
+	default:
+		return nil, errors.New("Invalid syslog network: " + network)
+	}
+	if facility > FacilityLocal7 || (facility > FacilityFTP && facility < FacilityLocal0) {
+		return nil, errors.New("Invalid syslog facility")
+	}
+	if network == "" && address != "" {
+		return nil, errors.New("Syslog address given without a network")
+	}
+
+	hostname, _ := os.Hostname()
+	s := &SyslogSink{
+		network:  network,
+		address:  address,
+		facility: facility,
+		app:      app,
+		hostname: hostname,
+	}
+	if err := s.connect(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SetFormat sets the message format, RFC5424 by default.
+func (s *SyslogSink) SetFormat(f SyslogFormat) error {
+	if f > RFC3164 {
+		return errors.New("Invalid syslog format")
+	}
+	s.mu.Lock()
+	s.format = f
+	s.mu.Unlock()
+	return nil
+}
+
+// SetHostname sets the host name sent with every message, the name of this machine by
+// default.
+func (s *SyslogSink) SetHostname(hostname string) {
+	s.mu.Lock()
+	s.hostname = hostname
+	s.mu.Unlock()
+}
+
+// Write sends an entry to the syslog server. If the connection was lost, Write reconnects
+// once and sends the entry again.
+func (s *SyslogSink) Write(en *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the framing depends on the connection, so it is applied after connecting
+	msg := s.message(en)
+	if s.conn != nil {
+		if _, err := s.conn.Write(s.frame(msg)); err == nil {
+			return nil
+		}
+		s.conn.Close()
+		s.conn = nil
+	}
+	if err := s.connect(); err != nil {
+		return err
+	}
+	_, err := s.conn.Write(s.frame(msg))
+	return err
+}
+
+// Close closes the connection to the syslog server.
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// connect opens the connection to the syslog server.
+func (s *SyslogSink) connect() error {
+	if s.network != "" {
+		conn, err := net.Dial(s.network, s.address)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+		return nil
+	}
+
+	for _, path := range localSyslogPaths {
+		for _, network := range []string{"unixgram", "unix"} {
+			if conn, err := net.Dial(network, path); err == nil {
+				s.conn = conn
+				return nil
+			}
+		}
+	}
+	return errors.New("Local syslog socket not found")
+}
+
+// frame returns msg framed for the transport of the connection.
+func (s *SyslogSink) frame(msg []byte) []byte {
+	switch s.conn.(type) {
+	case *net.TCPConn:
+		return append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+	case *net.UnixConn:
+		if addr := s.conn.RemoteAddr(); addr != nil && addr.Network() == "unix" {
+			return append(msg, '\n')
+		}
+	}
+	return msg
+}
+
+// message returns the syslog message for an entry.
+func (s *SyslogSink) message(en *Entry) []byte {
+	pri := int(s.facility)*8 + syslogSeverity(en.Level)
+	dst := []byte("<" + strconv.Itoa(pri) + ">")
+	if s.format == RFC3164 {
+		dst = en.Time.AppendFormat(dst, "Jan _2 15:04:05")
+		dst = append(dst, ' ')
+		dst = append(dst, orNilValue(s.hostname)...)
+		dst = append(dst, ' ')
+		dst = append(dst, orNilValue(s.app)...)
+		dst = append(dst, '[')
+		dst = strconv.AppendInt(dst, int64(os.Getpid()), 10)
+		dst = append(dst, "]: "...)
+		dst = append(dst, en.Message...)
+		return appendFields(dst, en.Fields)
+	}
+
+	dst = append(dst, "1 "...)
+	dst = en.Time.AppendFormat(dst, "2006-01-02T15:04:05.000000Z07:00")
+	dst = append(dst, ' ')
+	dst = append(dst, headerField(s.hostname, 255)...)
+	dst = append(dst, ' ')
+	dst = append(dst, headerField(s.app, 48)...)
+	dst = append(dst, ' ')
+	dst = strconv.AppendInt(dst, int64(os.Getpid()), 10)
+	dst = append(dst, " - "...)
+	dst = appendStructuredData(dst, en.Fields)
+	if en.Message != "" {
+		dst = append(dst, ' ')
+		dst = append(dst, en.Message...)
+	}
+	return dst
+}
+
+// syslogSeverity returns the syslog severity of a log level. Debug is 7, Info is 6,
+// Notice is 5 and Error is 3.
+func syslogSeverity(level LogLevel) int {
+	switch level {
+	case All:
+		return 7
+	case Verbose:
+		return 6
+	case Normal:
+		return 5
+	}
+	return 3
+}
+
+// appendStructuredData appends fields as an RFC 5424 structured data element, or "-" if
+// there are none.
+func appendStructuredData(dst []byte, fields []Field) []byte {
+	if len(fields) == 0 {
+		return append(dst, '-')
+	}
+	dst = append(dst, '[')
+	dst = append(dst, syslogSDID...)
+	for _, f := range fields {
+		dst = append(dst, ' ')
+		dst = append(dst, sdName(f.Key)...)
+		dst = append(dst, `="`...)
+		for _, c := range []byte(fmt.Sprint(f.Value)) {
+			if c == '"' || c == '\\' || c == ']' {
+				dst = append(dst, '\\')
+			}
+			dst = append(dst, c)
+		}
+		dst = append(dst, '"')
+	}
+	return append(dst, ']')
+}
+
+// sdName returns key as a valid structured data parameter name, printable ASCII without
+// spaces, '=', ']' or '"', and at most 32 characters.
+func sdName(key string) string {
+	b := []byte(key)
+	if len(b) > 32 {
+		b = b[:32]
+	}
+	for i, c := range b {
+		if c < 33 || c > 126 || c == '=' || c == ']' || c == '"' {
+			b[i] = '_'
+		}
+	}
+	if len(b) == 0 {
+		return "_"
+	}
+	return string(b)
+}
+
+// headerField returns s as an RFC 5424 header field of at most n characters, or "-" if s
+// is empty.
+func headerField(s string, n int) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+		return r
+	}, s)
+	if len(s) > n {
+		s = s[:n]
+	}
+	return orNilValue(s)
+}
+
+// orNilValue returns s, or the syslog nil value "-" if s is empty.
+func orNilValue(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,224 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewSyslogSink(t *testing.T) {
+	if _, err := NewSyslogSink("http", "localhost:514", FacilityUser, "app"); err == nil {
+		t.Errorf("Invalid network did not trigger error")
+	}
+	if _, err := NewSyslogSink("udp", "127.0.0.1:514", FacilityFTP+1, "app"); err == nil {
+		t.Errorf("Invalid facility did not trigger error")
+	}
+	if _, err := NewSyslogSink("", "127.0.0.1:514", FacilityUser, "app"); err == nil {
+		t.Errorf("Address without network did not trigger error")
+	}
+	if _, err := NewSyslogSink("tcp", "127.0.0.1:1", FacilityUser, "app"); err == nil {
+		t.Errorf("Unreachable server did not trigger error")
+	}
+}
+
+func TestSyslogSinkUDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("UDP not available: %v", err)
+	}
+	defer pc.Close()
+
+	sink, err := NewSyslogSink("udp", pc.LocalAddr().String(), FacilityLocal3, "myapp")
+	if err != nil {
+		t.Fatalf("Error creating syslog sink: %v", err)
+	}
+	sink.SetHostname("host")
+	test := New(false, false)
+	defer discardStderr(t)()
+	test.AddSink(sink)
+	now := time.Date(2018, 2, 5, 14, 3, 4, 5000, time.UTC)
+	test.SetClock(ClockFunc(func() time.Time { return now }))
+
+	test.Notice.LogFieldsFunc("Disk low", func() []Field { return []Field{{"free", 12}, {"path", `/a "b"`}} })
+	pc.SetReadDeadline(time.Now().Add(time.Second))
+	buf := make([]byte, 2048)
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("Error reading syslog message: %v", err)
+	}
+	// local3 is 19, notice is 5
+	expected := `<157>1 2018-02-05T14:03:04.000005Z host myapp ` + strconv.Itoa(os.Getpid()) +
+		` - [fields@32473 free="12" path="/a \"b\""] Disk low`
+	if actual := string(buf[:n]); actual != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, actual)
+	}
+
+	sink.SetFormat(RFC3164)
+	test.Error.Log("Failed")
+	n, _, err = pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("Error reading syslog message: %v", err)
+	}
+	expected = "<155>Feb  5 14:03:04 host myapp[" + strconv.Itoa(os.Getpid()) + "]: Failed"
+	if actual := string(buf[:n]); actual != expected {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", expected, actual)
+	}
+
+	if err := test.Close(time.Second); err != nil {
+		t.Errorf("Error closing logger: %v", err)
+	}
+	if sink.conn != nil {
+		t.Errorf("Sink was not closed with the logger")
+	}
+}
+
+func TestSyslogSinkTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("TCP not available: %v", err)
+	}
+	defer ln.Close()
+	frames := make(chan string, 4)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			size, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(size))
+			b := make([]byte, n)
+			if _, err := io.ReadFull(r, b); err != nil {
+				return
+			}
+			frames <- string(b)
+		}
+	}()
+
+	sink, err := NewSyslogSink("tcp", ln.Addr().String(), FacilityDaemon, "")
+	if err != nil {
+		t.Fatalf("Error creating syslog sink: %v", err)
+	}
+	defer sink.Close()
+	test := New(false, false)
+	test.SetLogLevel(All)
+	defer discardStderr(t)()
+	test.AddSink(sink)
+
+	test.Debug.Log("one two")
+	test.Info.Log("three")
+	// daemon is 3, debug is 7 and info is 6
+	for _, expected := range []string{`^<31>1 \S+ \S+ - \d+ - - one two$`, `^<30>1 \S+ \S+ - \d+ - - three$`} {
+		select {
+		case frame := <-frames:
+			if !regexp.MustCompile(expected).MatchString(frame) {
+				t.Errorf("Frame does not match, expected '%v' got '%v'", expected, frame)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for syslog message")
+		}
+	}
+}
+
+func TestSyslogSinkTCPReconnect(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("TCP not available: %v", err)
+	}
+	defer ln.Close()
+	lines := make(chan string, 4)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				b := make([]byte, 512)
+				// the first connection is closed without a message
+				if n, _ := conn.Read(b); n != 0 {
+					lines <- string(b[:n])
+				}
+			}()
+		}
+	}()
+
+	sink, err := NewSyslogSink("tcp", ln.Addr().String(), FacilityUser, "app")
+	if err != nil {
+		t.Fatalf("Error creating syslog sink: %v", err)
+	}
+	defer sink.Close()
+	// drop the connection, as after a failed reconnect
+	sink.Close()
+	if err := sink.Write(&Entry{Time: time.Now(), Level: ErrorsOnly, Message: "again"}); err != nil {
+		t.Fatalf("Error writing entry: %v", err)
+	}
+	select {
+	case line := <-lines:
+		if !regexp.MustCompile(`^\d+ <11>1 `).MatchString(line) {
+			t.Errorf("Message sent after reconnecting was not framed, got '%q'", line)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for syslog message")
+	}
+}
+
+func TestSyslogSinkUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("Unix sockets not available: %v", err)
+	}
+	defer conn.Close()
+
+	sink, err := NewSyslogSink("unixgram", path, FacilityAuth, "app")
+	if err != nil {
+		t.Fatalf("Error creating syslog sink: %v", err)
+	}
+	defer sink.Close()
+	sink.Write(&Entry{Time: time.Now(), Level: ErrorsOnly, Message: "denied"})
+
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	buf := make([]byte, 2048)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("Error reading syslog message: %v", err)
+	}
+	// auth is 4, error is 3
+	if actual := string(buf[:n]); !strings.HasPrefix(actual, "<35>1 ") || !strings.HasSuffix(actual, " - - denied") {
+		t.Errorf("Unexpected syslog message '%v'", actual)
+	}
+}
+
+func TestSdName(t *testing.T) {
+	cases := map[string]string{
+		"key":                   "key",
+		"a b=c]\"":              "a_b_c__",
+		"":                      "_",
+		strings.Repeat("k", 40): strings.Repeat("k", 32),
+		"café":                  "caf__",
+	}
+	for key, expected := range cases {
+		if actual := sdName(key); actual != expected {
+			t.Errorf("Names do not match, expected '%v' got '%v'", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultTemplate is a template that lays out entries like the built in layout, without
+// its column padding.
+const DefaultTemplate = "{?{time} - }{level}{? [{trace}]}{? {caller}} {msg}{? {fields}}"
+
+// maxTemplateWidth is the largest width allowed in a template field.
+const maxTemplateWidth = 256
+
+// A template represents a parsed text layout. See SetTemplate for its syntax.
+type template struct {
+	segments []segment
+}
+
+// A segment represents literal text, a field or a conditional section of a template.
+type segment struct {
+	text    string    // literal text
+	field   string    // name of the field
+	width   int       // minimum width of the field, negative to pad on the right
+	section []segment // segments of a conditional section
+}
+
+// templateFields are the names of the fields that can be used in a template.
+var templateFields = map[string]bool{
+	"time":   true,
+	"level":  true,
+	"trace":  true,
+	"caller": true,
+	"msg":    true,
+	"fields": true,
+}
+
+// SetTemplate sets the text layout of every log event. See Event.SetTemplate.
+func (l *Logger) SetTemplate(tmpl string) error {
+	t, err := parseTemplate(tmpl)
+	if err != nil {
+		return err
+	}
+	for _, e := range l.events() {
+		e.template = t
+	}
+	return nil
+}
+
+// SetTemplate sets the text layout of the event, such as "{time} [{level}] {msg}".
+// Fields are written as {name} or {name:width}. A positive width pads the field on the
+// left and a negative width pads it on the right. The fields are time, level, trace,
+// caller, msg and fields, trace being the short trace ID of entries logged with
+// LogContext. Text between {? and } is a conditional section, it is left out when every
+// field in it is empty, for example "{?({caller}) }". Use {{ and }} for literal braces.
+// An empty template restores the built in layout.
+func (e *Event) SetTemplate(tmpl string) error {
+	t, err := parseTemplate(tmpl)
+	if err != nil {
+		return err
+	}
+	e.template = t
+	return nil
+}
+
+// parseTemplate parses a template. An empty template returns nil.
+func parseTemplate(s string) (*template, error) {
+	if s == "" {
+		return nil, nil
+	}
+	segments, n, err := parseSegments(s, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	if n != len(s) {
+		return nil, templateError(s, n, "unexpected }")
+	}
+	return &template{segments}, nil
+}
+
+// parseSegments parses segments from s starting at offset i, until the end of s or the
+// closing brace of a section. It returns the offset after the last parsed segment.
+func parseSegments(s string, i int, section bool) ([]segment, int, error) {
+	var segments []segment
+	var text strings.Builder
+	flush := func() {
+		if text.Len() != 0 {
+			segments = append(segments, segment{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for i < len(s) {
+		switch {
+		case strings.HasPrefix(s[i:], "{{"):
+			text.WriteByte('{')
+			i += 2
+		case !section && strings.HasPrefix(s[i:], "}}"):
+			text.WriteByte('}')
+			i += 2
+		case s[i] == '}':
+			if !section {
+				return nil, i, templateError(s, i, "unexpected }")
+			}
+			flush()
+			return segments, i, nil
+		case strings.HasPrefix(s[i:], "{?"):
+			flush()
+			inner, n, err := parseSegments(s, i+2, true)
+			if err != nil {
+				return nil, n, err
+			}
+			if n == len(s) {
+				return nil, i, templateError(s, i, "unterminated section")
+			}
+			if !hasField(inner) {
+				return nil, i, templateError(s, i, "section without a field")
+			}
+			segments = append(segments, segment{section: inner})
+			i = n + 1
+		case s[i] == '{':
+			flush()
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return nil, i, templateError(s, i, "unterminated field")
+			}
+			seg, err := parseField(s, i, s[i+1:i+end])
+			if err != nil {
+				return nil, i, err
+			}
+			segments = append(segments, seg)
+			i += end + 1
+		default:
+			text.WriteByte(s[i])
+			i++
+		}
+	}
+	flush()
+	return segments, i, nil
+}
+
+// parseField parses the field spec found at offset i of s, such as "level:-7".
+func parseField(s string, i int, spec string) (segment, error) {
+	name, width, hasWidth := strings.Cut(spec, ":")
+	if !templateFields[name] {
+		return segment{}, templateError(s, i, fmt.Sprintf("unknown field %q", name))
+	}
+	seg := segment{field: name}
+	if hasWidth {
+		w, err := strconv.Atoi(width)
+		if err != nil || w == 0 || w > maxTemplateWidth || w < -maxTemplateWidth {
+			return segment{}, templateError(s, i, fmt.Sprintf("invalid width %q for field %q", width, name))
+		}
+		seg.width = w
+	}
+	return seg, nil
+}
+
+// hasField returns true if any of the segments is a field.
+func hasField(segments []segment) bool {
+	for _, seg := range segments {
+		if seg.field != "" || hasField(seg.section) {
+			return true
+		}
+	}
+	return false
+}
+
+// templateError returns a descriptive error for a problem at offset i of template s.
+func templateError(s string, i int, problem string) error {
+	return fmt.Errorf("Invalid template %q: %v at offset %v", s, problem, i)
+}
+
+// appendEntry appends the entry rendered with the template in the style of an output to
+// dst.
+func (t *template) appendEntry(dst []byte, e *Event, en *entry, st OutputStyle) ([]byte, error) {
+	dst, _, err := t.appendSegments(dst, t.segments, e, en, st)
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, '\n'), nil
+}
+
+// appendSegments appends the segments to dst. It reports whether any field was not empty.
+func (t *template) appendSegments(dst []byte, segments []segment, e *Event, en *entry, st OutputStyle) ([]byte, bool, error) {
+	var shown bool
+	for _, seg := range segments {
+		switch {
+		case seg.section != nil:
+			mark := len(dst)
+			var ok bool
+			var err error
+			if dst, ok, err = t.appendSegments(dst, seg.section, e, en, st); err != nil {
+				return dst, shown, err
+			}
+			if !ok {
+				dst = dst[:mark]
+			}
+			shown = shown || ok
+		case seg.field != "":
+			mark := len(dst)
+			var err error
+			if dst, err = e.appendField(dst, seg.field, en, st); err != nil {
+				return dst, shown, err
+			}
+			if len(dst) == mark {
+				dst = appendPadding(dst, abs(seg.width))
+				continue
+			}
+			shown = true
+			if pad := abs(seg.width) - visibleWidth(dst[mark:]); pad > 0 && seg.width > 0 {
+				dst = appendPadding(dst, pad)
+				copy(dst[mark+pad:], dst[mark:len(dst)-pad])
+				for i := mark; i < mark+pad; i++ {
+					dst[i] = ' '
+				}
+			} else {
+				dst = appendPadding(dst, pad)
+			}
+		default:
+			dst = append(dst, seg.text...)
+		}
+	}
+	return dst, shown, nil
+}
+
+// appendField appends the named template field of the entry to dst.
+func (e *Event) appendField(dst []byte, name string, en *entry, st OutputStyle) ([]byte, error) {
+	colored := e.colorFor(st)
+	switch name {
+	case "time":
+		if e.Logger.timestamp && e.timestamp {
+			return e.appendTimestampText(dst, en, st)
+		}
+	case "level":
+		return appendColored(dst, e.Prefix(), e.shownColors(), colored && (e.cformat&Prefix) == Prefix), nil
+	case "trace":
+		return appendShortTrace(dst, en), nil
+	case "caller":
+		return e.appendCaller(dst, en, colored), nil
+	case "msg":
+		return e.appendMessage(dst, en, colored), nil
+	case "fields":
+		mark := len(dst)
+		dst = e.appendFields(dst, en, colored)
+		if len(dst) > mark {
+			// drop the space in front of the first field
+			dst = append(dst[:mark], dst[mark+1:]...)
+		}
+	}
+	return dst, nil
+}
@@ -0,0 +1,124 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTemplate(t *testing.T) {
+	valid := []string{
+		"",
+		DefaultTemplate,
+		"{time} [{level:-7}] {caller} {msg} {fields}",
+		"{{{msg}}}",
+		"{?({caller}) }{msg}",
+		"{?{?{caller}:}{fields}}",
+	}
+	for _, tmpl := range valid {
+		if _, err := parseTemplate(tmpl); err != nil {
+			t.Errorf("Valid template '%v' triggered error: %v", tmpl, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"{lvl}":        `unknown field "lvl" at offset 0`,
+		"{msg":         "unterminated field at offset 0",
+		"{msg}}":       "unexpected } at offset 5",
+		"{? {msg}":     "unterminated section at offset 0",
+		"{? text}":     "section without a field at offset 0",
+		"{level:wide}": `invalid width "wide" for field "level"`,
+		"{level:0}":    `invalid width "0" for field "level"`,
+		"{level:1000}": `invalid width "1000" for field "level"`,
+	}
+	for tmpl, expected := range invalid {
+		_, err := parseTemplate(tmpl)
+		if err == nil {
+			t.Errorf("Invalid template '%v' did not trigger error", tmpl)
+			continue
+		}
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Error does not describe the problem, expected '%v' in '%v'", expected, err)
+		}
+	}
+}
+
+func TestEventSetTemplate(t *testing.T) {
+	test := New(true, false)
+	now := time.Date(2018, 2, 5, 14, 3, 4, 0, time.UTC)
+	test.SetClock(ClockFunc(func() time.Time { return now }))
+	test.Error.SetLayout("15:04:05")
+	if err := test.Error.SetTemplate("{time} [{level:-7}]{? {caller}} {msg}{? ({fields})}"); err != nil {
+		t.Errorf("Error setting template: %v", err)
+	}
+	if err := test.Error.SetTemplate("{nope}"); err == nil {
+		t.Errorf("Invalid template did not trigger error")
+	}
+
+	res, _ := test.Error.Log("Test message")
+	if expected := "14:03:04 [ERROR: ] Test message\n"; res != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, res)
+	}
+
+	res, _ = test.Error.LogFieldsFunc("Test message", func() []Field { return []Field{{"id", 7}} })
+	if expected := "14:03:04 [ERROR: ] Test message (id=7)\n"; res != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, res)
+	}
+
+	test.ShowCaller(true)
+	test.Error.SetCallerFormat(ShortFile)
+	res, _ = test.Error.Log("Test message")
+	if !strings.HasPrefix(res, "14:03:04 [ERROR: ] template_test.go:") {
+		t.Errorf("Caller section not shown, got '%q'", res)
+	}
+
+	test.ShowCaller(false)
+	test.Error.SetTemplate("{level:8}|{msg:-6}|{time}")
+	test.Error.ShowTimestamp(false)
+	res, _ = test.Error.Log("abc")
+	if expected := "  ERROR:|abc   |\n"; res != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, res)
+	}
+
+	test.Error.SetTemplate("")
+	res, _ = test.Error.Log("abc")
+	if expected := "ERROR: abc\n"; res != expected {
+		t.Errorf("Built in layout not restored, expected '%q' got '%q'", expected, res)
+	}
+}
+
+func TestLoggerSetTemplate(t *testing.T) {
+	test := New(false, true)
+	test.SetColorLevel(BasicColor)
+	test.ShowCaller(false)
+	if err := test.SetTemplate("{level} {msg}"); err != nil {
+		t.Errorf("Error setting template: %v", err)
+	}
+	for _, e := range test.events() {
+		if e.template == nil {
+			t.Errorf("Template was not set for %v", e.Prefix())
+		}
+	}
+	if err := test.SetTemplate("{"); err == nil {
+		t.Errorf("Invalid template did not trigger error")
+	}
+
+	res, _ := test.Error.Log("abc")
+	if expected := esc + "31mERROR:" + clear + " abc\n"; res != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, res)
+	}
+
+	test.SetTemplate(DefaultTemplate)
+	test.ShowColor(false)
+	res, _ = test.Error.LogFieldsFunc("abc", func() []Field { return []Field{{"a", 1}} })
+	test.SetTemplate("")
+	expected, _ := test.Error.LogFieldsFunc("abc", func() []Field { return []Field{{"a", 1}} })
+	if res != expected {
+		t.Errorf("Default template does not match the built in layout, expected '%q' got '%q'", expected, res)
+	}
+}
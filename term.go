@@ -0,0 +1,48 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"os"
+)
+
+// DetectColor returns the logger to automatic color detection, undoing ShowColor and
+// SetColorLevel. Colors are only written to the stderr when it is a terminal, unless the
+// environment says otherwise. See colorSupport for the environment variables that are
+// checked.
+func (l *Logger) DetectColor() {
+	l.colored = true
+	l.colorAuto = true
+	l.ttyColor = colorSupport(os.Stderr)
+	l.colorLevel = detectColorLevel()
+}
+
+// useColor returns true if colors should be written to the stderr for this event.
+func (e *Event) useColor() bool {
+	return e.colorFor(e.Logger.terminalStyle())
+}
+
+// colorSupport returns true if colors should be written to f. The NO_COLOR,
+// FORCE_COLOR, CLICOLOR_FORCE, CLICOLOR and TERM environment variables are checked, in
+// that order, before checking whether f is a terminal.
+func colorSupport(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := os.Getenv("FORCE_COLOR"); force != "" {
+		return force != "0" && force != "false"
+	}
+	if force := os.Getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return true
+	}
+	if os.Getenv("CLICOLOR") == "0" {
+		return false
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return isTerminal(f)
+}
@@ -0,0 +1,95 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// clearColorEnv unsets every environment variable checked by colorSupport for the
+// duration of the test.
+func clearColorEnv(t *testing.T) {
+	for _, key := range []string{"NO_COLOR", "FORCE_COLOR", "CLICOLOR_FORCE", "CLICOLOR", "TERM"} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestColorSupport(t *testing.T) {
+	f, err := ioutil.TempFile("", "logger")
+	if err != nil {
+		t.Fatalf("Error creating temp file: %v", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	tests := []struct {
+		env      map[string]string
+		expected bool
+	}{
+		{map[string]string{}, false},
+		{map[string]string{"FORCE_COLOR": "1"}, true},
+		{map[string]string{"FORCE_COLOR": "0"}, false},
+		{map[string]string{"CLICOLOR_FORCE": "1"}, true},
+		{map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "1"}, false},
+		{map[string]string{"TERM": "dumb", "CLICOLOR_FORCE": "1"}, true},
+	}
+	for _, test := range tests {
+		clearColorEnv(t)
+		for key, value := range test.env {
+			os.Setenv(key, value)
+		}
+		if actual := colorSupport(f); actual != test.expected {
+			t.Errorf("Color support doesn't match for %v, expected '%v' got '%v'", test.env, test.expected, actual)
+		}
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	f, err := ioutil.TempFile("", "logger")
+	if err != nil {
+		t.Fatalf("Error creating temp file: %v", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if isTerminal(f) {
+		t.Errorf("Regular file reported as a terminal")
+	}
+	if isTerminal(nil) {
+		t.Errorf("Nil file reported as a terminal")
+	}
+}
+
+func TestLoggerDetectColor(t *testing.T) {
+	clearColorEnv(t)
+	os.Setenv("NO_COLOR", "1")
+	test := New()
+	if test.Error.useColor() {
+		t.Errorf("Colors used with NO_COLOR set")
+	}
+
+	test.ShowColor(true)
+	if !test.Error.useColor() {
+		t.Errorf("ShowColor did not override color detection")
+	}
+
+	test.DetectColor()
+	if test.Error.useColor() {
+		t.Errorf("DetectColor did not restore color detection")
+	}
+
+	os.Setenv("NO_COLOR", "")
+	os.Setenv("FORCE_COLOR", "1")
+	test = New()
+	if !test.Error.useColor() {
+		t.Errorf("Colors not used with FORCE_COLOR set")
+	}
+	test = New(true, false)
+	if test.Error.useColor() {
+		t.Errorf("Explicitly disabled colors were used")
+	}
+}
@@ -0,0 +1,147 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ThemeEnv is the environment variable read by SetThemeFromEnv.
+const ThemeEnv = "LOGGER_THEME"
+
+// A Style represents the colors and color format of a single log event.
+type Style struct {
+	Colors Color
+	Format ColorFormat
+}
+
+// A Theme represents the styles of every log event and the token colors used by the
+// Highlight color format. If Tokens is the zero value the default token colors are used.
+type Theme struct {
+	Debug  Style
+	Info   Style
+	Notice Style
+	Error  Style
+	Tokens TokenColors
+}
+
+var (
+	themesMu sync.RWMutex
+	themes   = map[string]Theme{
+		"default": {
+			Debug:  Style{GreenFg, Prefix},
+			Info:   Style{GrayFg, Prefix},
+			Notice: Style{YellowFg, Prefix},
+			Error:  Style{RedFg, Prefix},
+			Tokens: defaultTokens,
+		},
+		"solarized": {
+			Debug:  Style{FgRGB(0x2a, 0xa1, 0x98), Prefix},
+			Info:   Style{FgRGB(0x26, 0x8b, 0xd2), Prefix},
+			Notice: Style{FgRGB(0xb5, 0x89, 0x00), Prefix | Message},
+			Error:  Style{FgRGB(0xdc, 0x32, 0x2f), Prefix | Message},
+			Tokens: TokenColors{
+				Key:      FgRGB(0x65, 0x7b, 0x83),
+				Number:   FgRGB(0xd3, 0x36, 0x82),
+				String:   FgRGB(0x85, 0x99, 0x00),
+				Duration: FgRGB(0x6c, 0x71, 0xc4),
+				Error:    FgRGB(0xdc, 0x32, 0x2f) | Bold,
+			},
+		},
+		"monochrome-bold": {
+			Debug:  Style{Faint, Prefix},
+			Info:   Style{0, Prefix},
+			Notice: Style{Bold, Prefix},
+			Error:  Style{Bold | Inverse, Prefix},
+			Tokens: TokenColors{Key: Bold, Error: Bold | Underline},
+		},
+		"high-contrast": {
+			Debug:  Style{Bold | BlackFg | GreenBg, Prefix},
+			Info:   Style{Bold | BlackFg | GrayBg, Prefix},
+			Notice: Style{Bold | BlackFg | BrownBg, Prefix | Message},
+			Error:  Style{Bold | GrayFg | RedBg, Prefix | Message},
+			Tokens: TokenColors{
+				Key:      Bold | CyanFg,
+				Number:   Bold | MagentaFg,
+				String:   Bold | GreenFg,
+				Duration: Bold | BlueFg,
+				Error:    Bold | GrayFg | RedBg,
+			},
+		},
+	}
+)
+
+// RegisterTheme adds a theme that can be selected with SetTheme. Registering a theme with
+// the name of an existing theme replaces it. Names are not case sensitive.
+func RegisterTheme(name string, t Theme) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return errors.New("Invalid theme name")
+	}
+	for _, s := range t.styles() {
+		if (s.Format | cformatMask) != cformatMask {
+			return errors.New("Invalid color format")
+		}
+	}
+
+	themesMu.Lock()
+	themes[name] = t
+	themesMu.Unlock()
+	return nil
+}
+
+// Themes returns the names of the registered themes in alphabetical order.
+func Themes() []string {
+	themesMu.RLock()
+	defer themesMu.RUnlock()
+	var names []string
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetTheme sets the colors and color format of every log event, and the token colors of
+// the logger, from the registered theme with the given name.
+func (l *Logger) SetTheme(name string) error {
+	themesMu.RLock()
+	t, ok := themes[strings.ToLower(strings.TrimSpace(name))]
+	themesMu.RUnlock()
+	if !ok {
+		return errors.New("Unknown theme: " + name)
+	}
+
+	for i, e := range l.events() {
+		s := t.styles()[i]
+		e.SetColors(s.Colors)
+		e.cformat = s.Format
+	}
+	if t.Tokens == (TokenColors{}) {
+		t.Tokens = defaultTokens
+	}
+	l.SetTokenColors(t.Tokens)
+	return nil
+}
+
+// SetThemeFromEnv sets the theme named by the LOGGER_THEME environment variable. It does
+// nothing if the variable is not set.
+func (l *Logger) SetThemeFromEnv() error {
+	name := os.Getenv(ThemeEnv)
+	if name == "" {
+		return nil
+	}
+	return l.SetTheme(name)
+}
+
+// styles returns the styles of the theme in the same order as Logger.events.
+func (t Theme) styles() []Style {
+	return []Style{t.Debug, t.Info, t.Notice, t.Error}
+}
@@ -0,0 +1,96 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestLoggerSetTheme(t *testing.T) {
+	test := New()
+	if err := test.SetTheme("high-contrast"); err != nil {
+		t.Errorf("Error setting theme: %v", err)
+	}
+	if test.Error.colors != (Bold | GrayFg | RedBg) {
+		t.Errorf("Colors were not changed")
+	}
+	if test.Notice.cformat != (Prefix | Message) {
+		t.Errorf("Color format was not changed, expected '%v' got '%v'", Prefix|Message, test.Notice.cformat)
+	}
+
+	if err := test.SetTheme("Default"); err != nil {
+		t.Errorf("Error setting theme: %v", err)
+	}
+	expected := New()
+	for i, e := range test.events() {
+		ex := expected.events()[i]
+		if e.colors != ex.colors || e.cformat != ex.cformat {
+			t.Errorf("Default theme does not match the defaults of New for %v", e.Prefix())
+		}
+	}
+
+	if err := test.SetTheme("no-such-theme"); err == nil {
+		t.Errorf("Unknown theme did not trigger error")
+	}
+}
+
+func TestRegisterTheme(t *testing.T) {
+	theme := Theme{
+		Debug:  Style{CyanFg, Message},
+		Info:   Style{BlueFg, Message},
+		Notice: Style{MagentaFg, Message},
+		Error:  Style{RedFg | Bold, Timestamp | Prefix | Message},
+	}
+	if err := RegisterTheme("Custom", theme); err != nil {
+		t.Errorf("Error registering theme: %v", err)
+	}
+	if err := RegisterTheme(" ", theme); err == nil {
+		t.Errorf("Empty theme name did not trigger error")
+	}
+	theme.Info.Format = 123
+	if err := RegisterTheme("broken", theme); err == nil {
+		t.Errorf("Invalid color format did not trigger error")
+	}
+
+	test := New()
+	if err := test.SetTheme("custom"); err != nil {
+		t.Errorf("Error setting theme: %v", err)
+	}
+	if test.Error.colors != (RedFg|Bold) || test.Error.cformat != (Timestamp|Prefix|Message) {
+		t.Errorf("Registered theme was not applied")
+	}
+
+	names := Themes()
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("Theme names are not sorted, got '%v'", names)
+	}
+	i := sort.SearchStrings(names, "custom")
+	if i == len(names) || names[i] != "custom" {
+		t.Errorf("Registered theme not listed, got '%v'", names)
+	}
+}
+
+func TestLoggerSetThemeFromEnv(t *testing.T) {
+	test := New()
+	t.Setenv(ThemeEnv, "")
+	if err := test.SetThemeFromEnv(); err != nil {
+		t.Errorf("Unset theme variable triggered error: %v", err)
+	}
+
+	t.Setenv(ThemeEnv, "monochrome-bold")
+	if err := test.SetThemeFromEnv(); err != nil {
+		t.Errorf("Error setting theme: %v", err)
+	}
+	if test.Error.colors != (Bold | Inverse) {
+		t.Errorf("Theme from environment was not applied")
+	}
+
+	t.Setenv(ThemeEnv, "nope")
+	if err := test.SetThemeFromEnv(); err == nil {
+		t.Errorf("Unknown theme did not trigger error")
+	}
+}
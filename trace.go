@@ -0,0 +1,110 @@
+// Package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+)
+
+// shortTraceLen is the number of hex digits of the trace ID shown in text output.
+const shortTraceLen = 8
+
+// A TraceContext represents the W3C trace context of an entry, as carried by the
+// traceparent header. IDs are lowercase hex.
+type TraceContext struct {
+	TraceID string // 32 hex digits
+	SpanID  string // 16 hex digits, the parent-id of the header
+	Sampled bool
+}
+
+// traceKey is the context key of a TraceContext.
+type traceKey struct{}
+
+// ParseTraceparent parses a W3C traceparent header, such as
+// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01". Headers of later versions
+// are accepted as long as they start with the fields of version 00.
+func ParseTraceparent(s string) (TraceContext, error) {
+	invalid := errors.New("Invalid traceparent: " + s)
+	if len(s) < 55 || s[2] != '-' || s[35] != '-' || s[52] != '-' {
+		return TraceContext{}, invalid
+	}
+	version, ok := parseHexByte(s[:2])
+	if !ok || version == 0xff {
+		return TraceContext{}, invalid
+	}
+	if (version == 0 && len(s) != 55) || (len(s) > 55 && s[55] != '-') {
+		return TraceContext{}, invalid
+	}
+	flags, ok := parseHexByte(s[53:55])
+	if !ok || !validHexID(s[3:35], 16) || !validHexID(s[36:52], 8) {
+		return TraceContext{}, invalid
+	}
+	return TraceContext{TraceID: s[3:35], SpanID: s[36:52], Sampled: flags&1 == 1}, nil
+}
+
+// String returns the trace context as a version 00 traceparent header.
+func (tc TraceContext) String() string {
+	flags := "00"
+	if tc.Sampled {
+		flags = "01"
+	}
+	return "00-" + tc.TraceID + "-" + tc.SpanID + "-" + flags
+}
+
+// Valid returns true if the trace context has a trace ID and a span ID.
+func (tc TraceContext) Valid() bool {
+	return validHexID(tc.TraceID, 16) && validHexID(tc.SpanID, 8)
+}
+
+// Fields returns the trace_id and span_id fields of the trace context.
+func (tc TraceContext) Fields() []Field {
+	return []Field{{TraceIDKey, tc.TraceID}, {SpanIDKey, tc.SpanID}}
+}
+
+// ContextWithTrace returns a copy of ctx carrying the trace context. Entries logged with
+// Event.LogContext and that context are correlated with the trace.
+func ContextWithTrace(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceKey{}, tc)
+}
+
+// TraceFromContext returns the trace context carried by ctx, if any.
+func TraceFromContext(ctx context.Context) (TraceContext, bool) {
+	if ctx == nil {
+		return TraceContext{}, false
+	}
+	tc, ok := ctx.Value(traceKey{}).(TraceContext)
+	return tc, ok && tc.Valid()
+}
+
+// LogContext logs the given message like Log, along with the trace context carried by
+// ctx. The trace and span IDs are passed to the sinks as the trace_id and span_id fields,
+// and text output shows the first 8 digits of the trace ID after the prefix.
+func (e *Event) LogContext(ctx context.Context, fstring string, a ...interface{}) (string, error) {
+	if !e.Enabled() {
+		return "", nil
+	}
+	tc, _ := TraceFromContext(ctx)
+	return e.printf(tc, nil, fstring, a...)
+}
+
+// appendShortTrace appends the first digits of the trace ID of the entry, if it has one.
+func appendShortTrace(dst []byte, en *entry) []byte {
+	if len(en.trace.TraceID) < shortTraceLen {
+		return dst
+	}
+	return append(dst, en.trace.TraceID[:shortTraceLen]...)
+}
+
+// parseHexByte parses two lowercase hex digits.
+func parseHexByte(s string) (byte, bool) {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 1 || hex.EncodeToString(b) != s {
+		return 0, false
+	}
+	return b[0], true
+}
@@ -0,0 +1,100 @@
+// package logger
+// 19 October, 2026
+// Code is licensed under the MIT License
+// © 2018 Scott Isenberg
+
+package logger
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestParseTraceparent(t *testing.T) {
+	tc, err := ParseTraceparent(testTraceparent)
+	if err != nil {
+		t.Fatalf("Valid traceparent triggered error: %v", err)
+	}
+	expected := TraceContext{"4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", true}
+	if tc != expected {
+		t.Errorf("Trace contexts do not match, expected '%+v' got '%+v'", expected, tc)
+	}
+	if tc.String() != testTraceparent {
+		t.Errorf("Strings do not match, expected '%v' got '%v'", testTraceparent, tc.String())
+	}
+	if _, err := ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-extra"); err != nil {
+		t.Errorf("Later version triggered error: %v", err)
+	}
+
+	invalid := []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-0g",
+		"00_4bf92f3577b34da6a3ce929d0e0e4736_00f067aa0ba902b7_01",
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01x",
+	}
+	for _, s := range invalid {
+		if _, err := ParseTraceparent(s); err == nil {
+			t.Errorf("Invalid traceparent '%v' did not trigger error", s)
+		}
+	}
+}
+
+func TestTraceFromContext(t *testing.T) {
+	if _, ok := TraceFromContext(context.Background()); ok {
+		t.Errorf("Empty context returned a trace context")
+	}
+	tc, _ := ParseTraceparent(testTraceparent)
+	got, ok := TraceFromContext(ContextWithTrace(context.Background(), tc))
+	if !ok || got != tc {
+		t.Errorf("Trace contexts do not match, expected '%+v' got '%+v'", tc, got)
+	}
+}
+
+func TestEventLogContext(t *testing.T) {
+	defer discardStderr(t)()
+	test := New(false, false)
+	sink := &memorySink{}
+	test.AddSink(sink)
+	tc, _ := ParseTraceparent(testTraceparent)
+	ctx := ContextWithTrace(context.Background(), tc)
+
+	res, _ := test.Error.LogContext(ctx, "Failed %v", 3)
+	if expected := "ERROR: [4bf92f35] Failed 3\n"; res != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, res)
+	}
+	res, _ = test.Error.LogContext(context.Background(), "Failed")
+	if expected := "ERROR: Failed\n"; res != expected {
+		t.Errorf("Strings do not match, expected '%q' got '%q'", expected, res)
+	}
+	if len(sink.entries) != 2 {
+		t.Fatalf("Wrong number of entries, expected '%v' got '%v'", 2, len(sink.entries))
+	}
+	if expected := tc.Fields(); !reflect.DeepEqual(sink.entries[0].Fields, expected) {
+		t.Errorf("Fields do not match, expected '%v' got '%v'", expected, sink.entries[0].Fields)
+	}
+	if sink.entries[1].Fields != nil {
+		t.Errorf("Entry without trace has fields '%v'", sink.entries[1].Fields)
+	}
+	if r := sink.entries[0].OTLP(); r.TraceID != tc.TraceID || r.SpanID != tc.SpanID {
+		t.Errorf("Trace context was not exported, got '%v' '%v'", r.TraceID, r.SpanID)
+	}
+
+	test.SetTemplate(DefaultTemplate)
+	templated, _ := test.Error.LogContext(ctx, "Failed %v", 3)
+	if expected := "ERROR: [4bf92f35] Failed 3\n"; templated != expected {
+		t.Errorf("Default template does not match the built in layout, expected '%q' got '%q'", expected, templated)
+	}
+
+	test.SetLogLevel(ErrorsOnly)
+	if res, _ := test.Debug.LogContext(ctx, "hidden"); res != "" {
+		t.Errorf("Disabled event logged '%q'", res)
+	}
+}